@@ -1,7 +1,17 @@
 package main
 
-import "user-service/internal/app"
+import (
+	"log"
+	"os"
+	"user-service/internal/app"
+)
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := app.Migrate(os.Args[2:]); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
 	app.Run()
 }
@@ -1,20 +1,46 @@
 package app
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"os"
+	"strings"
+	"time"
+	"user-service/internal/auth"
+	auth_controller "user-service/internal/auth/delivery/http_controller"
+	auth_repository "user-service/internal/auth/repository"
+	auth_service "user-service/internal/auth/service"
 	"user-service/internal/config"
+	"user-service/internal/mapper"
+	"user-service/internal/messages"
+	"user-service/internal/middleware"
+	"user-service/internal/policy"
+	policy_controller "user-service/internal/policy/delivery/http_controller"
+	policy_service "user-service/internal/policy/service"
+	"user-service/internal/rbac"
+	rbac_controller "user-service/internal/rbac/delivery/http_controller"
+	rbac_repository "user-service/internal/rbac/repository"
+	rbac_service "user-service/internal/rbac/service"
 	"user-service/internal/server"
 	"user-service/internal/user"
 	"user-service/internal/user/delivery/http_controller"
 	"user-service/internal/user/repository"
 	"user-service/internal/user/service"
+	"user-service/pkg/abac"
 	"user-service/pkg/db"
+	"user-service/pkg/jwt"
+	"user-service/pkg/jwt/jwt_helper"
+	"user-service/pkg/password"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 const configPath string = "config/config"
+const policyPath string = "config/policies.yml"
 
 func Run() {
 	appConfig, err := config.LoadConfig(configPath)
@@ -22,22 +48,205 @@ func Run() {
 		log.Fatalln("Ошибка загрузки файла конфигурации.", err)
 	}
 
-	database, err := db.New(appConfig.GetDatabaseConfig())
+	var serverConfig config.ServerConfig = appConfig.GetServerConfig()
+	if lang := serverConfig.GetDefaultLanguage(); lang != "" {
+		if err := messages.SetDefaultLanguage(lang); err != nil {
+			log.Fatalln("Ошибка настройки языка сообщений по умолчанию.", err)
+		}
+	}
+
+	var databaseConfig config.DatabaseConfig = appConfig.GetDatabaseConfig()
+	database, err := db.New(databaseConfig)
+	if err != nil {
+		log.Fatalln("Ошибка инициализации пула соединений с базой данных.", err)
+	}
+	defer database.Close()
+	if databaseConfig.GetMigrateOnStart() {
+		if err := migrateUp(database); err != nil {
+			// log.Fatalln не выполняет отложенные вызовы, поэтому пул закрывается явно
+			database.Close()
+			log.Fatalln("Ошибка применения миграций базы данных.", err)
+		}
+	}
+	prometheus.MustRegister(collectors.NewDBStatsCollector(database.Pool(), databaseConfig.GetDBName()))
+
+	passwordHasher, err := newPasswordHasher(appConfig.GetPasswordConfig())
 	if err != nil {
-		log.Fatalln("Ошибка инициализации экземпляра драйвера базы данных.", err)
+		log.Fatalln("Ошибка инициализации алгоритма хеширования паролей.", err)
 	}
+	mapper.SetPasswordHasher(passwordHasher)
 
 	var userRepository user.UserRepository = repository.NewUserRepository(database)
-	var userService user.UserService = service.NewUserService(userRepository)
+	var userService user.UserService = service.NewUserService(userRepository, database, databaseConfig.GetCountEstimateThreshold())
 	var userController user.UserController = http_controller.NewUserController(userService)
 
+	var roleRepository rbac.RoleRepository = rbac_repository.NewRoleRepository(database)
+	var permissionRepository rbac.PermissionRepository = rbac_repository.NewPermissionRepository(database)
+	var userRoleRepository rbac.UserRoleRepository = rbac_repository.NewUserRoleRepository(database)
+	var rbacService rbac.RBACService = rbac_service.NewRBACService(
+		roleRepository,
+		permissionRepository,
+		userRoleRepository,
+		userRepository,
+	)
+	var rbacController rbac.RBACController = rbac_controller.NewRBACController(rbacService)
+
+	policyEngine, err := abac.Load(policyPath)
+	if err != nil {
+		log.Fatalln("Ошибка загрузки правил доступа.", err)
+	}
+	var policyService policy.PolicyService = policy_service.NewPolicyService(policyEngine, userRepository)
+	var policyController policy.PolicyController = policy_controller.NewPolicyController(policyService)
+
+	jwtCoder, err := newJWTCoder(appConfig.GetJWTConfig())
+	if err != nil {
+		log.Fatalln("Ошибка инициализации кодировщика JWT.", err)
+	}
+	middleware.SetJWTCoder(jwtCoder)
+
+	var refreshTokenRepository auth.RefreshTokenRepository = auth_repository.NewRefreshTokenRepository(database)
+	var denylistRepository auth.TokenDenylistRepository = auth_repository.NewTokenDenylistRepository(database)
+	middleware.SetTokenDenylist(denylistRepository)
+
+	var authConfig config.AuthConfig = appConfig.GetAuthConfig()
+	authService, err := auth_service.NewAuthService(
+		userRepository,
+		userRoleRepository,
+		refreshTokenRepository,
+		denylistRepository,
+		database,
+		passwordHasher,
+		jwtCoder,
+		authConfig.GetRefreshTokenTTL(),
+	)
+	if err != nil {
+		log.Fatalln("Ошибка инициализации сервиса аутентификации.", err)
+	}
+	var authController auth.AuthController = auth_controller.NewAuthController(authService)
+
+	go pruneRevokedTokens(authService, authConfig.GetDenylistPruneInterval())
+
 	routerInstance := mux.NewRouter()
-	user.SetupUserRoutes(routerInstance, userController)
+	routerInstance.Use(middleware.Localize)
+	user.SetupUserRoutes(routerInstance, userController, policyService)
+	auth.SetupAuthRoutes(routerInstance, authController)
+	rbac.SetupRBACRoutes(routerInstance, rbacController)
+	policy.SetupPolicyRoutes(routerInstance, policyController)
 
 	routerInstance.Handle("/metrics", promhttp.Handler())
 
-	var serverConfig config.ServerConfig = appConfig.GetServerConfig()
 	if err := server.Run(routerInstance, serverConfig.GetHost(), serverConfig.GetPort()); err != nil {
 		log.Fatalln("Ошибка запуска веб-сервера.", err)
 	}
 }
+
+// pruneRevokedTokens периодически удаляет истекшие записи из списка отозванных токенов
+func pruneRevokedTokens(authService auth.AuthService, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		count, err := authService.PruneRevokedTokens(context.Background())
+		if err != nil {
+			log.Println("Ошибка удаления истекших отозванных токенов.", err)
+			continue
+		}
+		log.Println("Удалено истекших отозванных токенов:", count)
+	}
+}
+
+// newPasswordHasher создает алгоритм хеширования новых паролей. Хеши остальных
+// поддерживаемых алгоритмов, в том числе устаревшие SHA-256, принимаются при входе
+// и заменяются хешем текущего алгоритма.
+func newPasswordHasher(passwordConfig config.PasswordConfig) (*password.PasswordHasher, error) {
+	argon2idConfig := passwordConfig.GetArgon2idConfig()
+	argon2idHasher := password.NewArgon2idHasher(password.Argon2idParams{
+		Memory:      argon2idConfig.GetMemory(),
+		Iterations:  argon2idConfig.GetIterations(),
+		Parallelism: argon2idConfig.GetParallelism(),
+		SaltLength:  argon2idConfig.GetSaltLength(),
+		KeyLength:   argon2idConfig.GetKeyLength(),
+	})
+	bcryptConfig := passwordConfig.GetBcryptConfig()
+	bcryptHasher := password.NewBcryptHasher(bcryptConfig.GetCost())
+
+	switch passwordConfig.GetAlgorithm() {
+	case "argon2id":
+		return password.New(argon2idHasher, bcryptHasher, password.NewSHA256Hasher()), nil
+	case "bcrypt":
+		return password.New(bcryptHasher, argon2idHasher, password.NewSHA256Hasher()), nil
+	default:
+		return nil, fmt.Errorf("неподдерживаемый алгоритм хеширования паролей '%s'", passwordConfig.GetAlgorithm())
+	}
+}
+
+// newJWTCoder создает кодировщик JWT с активным ключом подписи и ключами,
+// выведенными из оборота, которые еще нужны для проверки выпущенных ими токенов
+func newJWTCoder(jwtConfig config.JWTConfig) (*jwt.JWTCoder, error) {
+	activeKeyConfig := jwtConfig.GetActiveKey()
+	activeKey, err := newJWTKey(activeKeyConfig)
+	if err != nil {
+		return nil, err
+	}
+	// Ошибки ключей обнаруживаются при запуске, а не при первом выпуске или проверке токена
+	if err := activeKey.Validate(activeKeyConfig.GetAlg(), true); err != nil {
+		return nil, fmt.Errorf("ключ '%s' с алгоритмом %s: %w", activeKeyConfig.GetKid(), activeKeyConfig.GetAlg(), err)
+	}
+
+	keyring := jwt.NewKeyring(activeKeyConfig.GetAlg(), activeKey)
+	for _, retiredKeyConfig := range jwtConfig.GetRetiredKeys() {
+		retiredKey, err := newJWTKey(retiredKeyConfig)
+		if err != nil {
+			return nil, err
+		}
+		if err := retiredKey.Validate(retiredKeyConfig.GetAlg(), false); err != nil {
+			return nil, fmt.Errorf("ключ '%s' с алгоритмом %s: %w", retiredKeyConfig.GetKid(), retiredKeyConfig.GetAlg(), err)
+		}
+		if err := keyring.AddRetired(retiredKeyConfig.GetAlg(), retiredKey); err != nil {
+			return nil, fmt.Errorf("ключ '%s': %w", retiredKeyConfig.GetKid(), err)
+		}
+	}
+
+	return jwt.NewJWTCoderWithKeyring(
+		keyring,
+		jwtConfig.GetIssuer(),
+		jwtConfig.GetAudience(),
+		jwtConfig.GetExpiration(),
+	).WithLeeway(jwtConfig.GetLeeway()), nil
+}
+
+// newJWTKey загружает ключ: для HMAC-алгоритмов используется секрет,
+// для асимметричных — ключи в формате PEM. Если задан только открытый ключ,
+// им можно проверять токены, но не выпускать их.
+func newJWTKey(keyConfig config.JWTKeyConfig) (*jwt.Key, error) {
+	var key *jwt.Key
+	switch {
+	case strings.HasPrefix(keyConfig.GetAlg(), "HS"):
+		key = jwt.NewSecretKey(keyConfig.GetSecret())
+	case keyConfig.GetPrivateKeyPath() != "":
+		data, err := os.ReadFile(keyConfig.GetPrivateKeyPath())
+		if err != nil {
+			return nil, err
+		}
+		privateKey, err := jwt_helper.ParsePrivateKeyPEM(data)
+		if err != nil {
+			return nil, err
+		}
+		key = jwt.NewPrivateKey(privateKey)
+	case keyConfig.GetPublicKeyPath() != "":
+		data, err := os.ReadFile(keyConfig.GetPublicKeyPath())
+		if err != nil {
+			return nil, err
+		}
+		publicKey, err := jwt_helper.ParsePublicKeyPEM(data)
+		if err != nil {
+			return nil, err
+		}
+		key = jwt.NewPublicKey(publicKey)
+	default:
+		return nil, fmt.Errorf("для ключа '%s' с алгоритмом %s не задан путь к ключу", keyConfig.GetKid(), keyConfig.GetAlg())
+	}
+	return key.WithID(keyConfig.GetKid()), nil
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"user-service/internal/config"
+	"user-service/internal/migrations"
+	"user-service/pkg/db"
+	"user-service/pkg/migrate"
+)
+
+// errMigrateUsage означает, что подкоманда migrate вызвана с неверными аргументами
+var errMigrateUsage error = errors.New("Использование: app migrate up|down|status|goto <версия>")
+
+// Migrate выполняет подкоманду migrate: up применяет все миграции, down откатывает
+// последнюю, status выводит состояние миграций, goto переводит схему на заданную версию.
+// Ошибка возвращается после закрытия пула соединений, чтобы вызывающий мог завершить процесс.
+func Migrate(args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	appConfig, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("ошибка загрузки файла конфигурации: %w", err)
+	}
+	database, err := db.New(appConfig.GetDatabaseConfig())
+	if err != nil {
+		return fmt.Errorf("ошибка инициализации пула соединений с базой данных: %w", err)
+	}
+	defer database.Close()
+
+	migrator, err := newMigrator(database)
+	if err != nil {
+		return fmt.Errorf("ошибка загрузки миграций: %w", err)
+	}
+
+	ctx := context.Background()
+	var executed []migrate.Migration
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		executed, err = migrator.Up(ctx)
+	case args[0] == "down" && len(args) == 1:
+		executed, err = migrator.Down(ctx)
+	case args[0] == "goto" && len(args) == 2:
+		version, parseErr := strconv.ParseInt(args[1], 10, 64)
+		if parseErr != nil || version < 0 {
+			return errMigrateUsage
+		}
+		executed, err = migrator.Goto(ctx, version)
+	case args[0] == "status" && len(args) == 1:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return fmt.Errorf("ошибка получения состояния миграций: %w", err)
+		}
+		for _, status := range statuses {
+			appliedAt := "не применена"
+			if status.Applied {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d  %-32s  %s\n", status.Version, status.Name, appliedAt)
+		}
+		return nil
+	default:
+		return errMigrateUsage
+	}
+
+	logExecuted(executed)
+	if err != nil {
+		return fmt.Errorf("ошибка выполнения миграций: %w", err)
+	}
+	return nil
+}
+
+// migrateUp применяет непримененные миграции при запуске сервиса
+func migrateUp(database db.DB) error {
+	migrator, err := newMigrator(database)
+	if err != nil {
+		return err
+	}
+	executed, err := migrator.Up(context.Background())
+	logExecuted(executed)
+	if err == nil && len(executed) == 0 {
+		log.Println("Схема базы данных актуальна, миграции не выполнялись")
+	}
+	return err
+}
+
+func newMigrator(database db.DB) (*migrate.Migrator, error) {
+	migrationList, err := migrate.Load(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(database.Pool(), migrationList), nil
+}
+
+func logExecuted(executed []migrate.Migration) {
+	for _, migration := range executed {
+		log.Printf("Выполнена миграция %04d_%s", migration.Version, migration.Name)
+	}
+}
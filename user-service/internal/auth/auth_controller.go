@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"net/http"
+	"user-service/internal/middleware"
+
+	"github.com/gorilla/mux"
+)
+
+type AuthController interface {
+	Login() http.Handler
+	Refresh() http.Handler
+	Logout() http.Handler
+	Revoke() http.Handler
+	JWKS() http.Handler
+}
+
+func SetupAuthRoutes(router *mux.Router, authController AuthController) *mux.Router {
+
+	postRoutes := router.Methods(http.MethodPost).Subrouter()
+	postRoutes.Handle("/auth/login", authController.Login())
+	postRoutes.Handle("/auth/refresh", authController.Refresh())
+	postRoutes.Handle("/auth/logout", middleware.IsAuthenticatedMiddleware(authController.Logout()))
+	postRoutes.Handle("/auth/revoke", middleware.IsAdminMiddleware(authController.Revoke()))
+
+	getRoutes := router.Methods(http.MethodGet).Subrouter()
+	getRoutes.Handle("/.well-known/jwks.json", authController.JWKS())
+
+	return router
+}
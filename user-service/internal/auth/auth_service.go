@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"user-service/internal/model"
+	"user-service/pkg/jwt/jwt_metadata"
+)
+
+// ErrInvalidCredentials возвращается при неверном имени пользователя или пароле.
+// Намеренно не различает эти случаи, чтобы не раскрывать существование пользователя.
+var ErrInvalidCredentials error = errors.New("неверное имя пользователя или пароль")
+
+// ErrInvalidRefreshToken возвращается, если refresh-токен не найден, истек или отозван
+var ErrInvalidRefreshToken error = errors.New("недействительный refresh-токен")
+
+// ErrRefreshTokenReused возвращается при повторном предъявлении уже использованного refresh-токена.
+// В этом случае отзывается все семейство токенов, выданных в рамках одной сессии.
+var ErrRefreshTokenReused error = errors.New("повторное использование refresh-токена")
+
+// ErrInvalidToken возвращается, если отзываемый access-токен не удалось разобрать или проверить
+var ErrInvalidToken error = errors.New("недействительный токен")
+
+type AuthService interface {
+	Login(ctx context.Context, username, password string) (*model.AuthTokenModel, error)
+	Refresh(ctx context.Context, refreshToken string) (*model.AuthTokenModel, error)
+	Logout(ctx context.Context, payload *jwt_metadata.Payload, refreshToken string) error
+	Revoke(ctx context.Context, token string) error
+	PruneRevokedTokens(ctx context.Context) (int64, error)
+	JWKS() jwt_metadata.JWKSet
+}
@@ -0,0 +1,227 @@
+package http_controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"user-service/internal/auth"
+	"user-service/internal/dto"
+	"user-service/internal/http_response"
+	"user-service/internal/mapper"
+	"user-service/internal/messages"
+	"user-service/internal/middleware"
+
+	"github.com/go-playground/validator"
+)
+
+type authControllerImpl struct {
+	authService auth.AuthService
+	authMapper  mapper.AuthMapper
+}
+
+// Login implements auth.AuthController.
+func (authController *authControllerImpl) Login() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "AuthController.Login:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			var dtoRequest dto.LoginRequest
+			if !readRequest(responseWriter, request, handlerName, &dtoRequest, "dto.LoginRequest") {
+				return
+			}
+
+			authTokenModel, err := authController.authService.Login(request.Context(), dtoRequest.Username, dtoRequest.Password)
+			if err != nil {
+				if errors.Is(err, auth.ErrInvalidCredentials) {
+					http_response.WriteError(responseWriter, request, handlerName, http.StatusUnauthorized, err, messages.AuthInvalidCredentials)
+					return
+				}
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusInternalServerError, err, messages.AuthLoginFailed)
+				return
+			}
+
+			if !writeTokens(responseWriter, request, handlerName, authController.authMapper.ToDto(*authTokenModel)) {
+				return
+			}
+
+			log.Println(handlerName, request.URL.Path, "from", request.Host, "token issued for", dtoRequest.Username)
+		},
+	)
+}
+
+// Refresh implements auth.AuthController.
+func (authController *authControllerImpl) Refresh() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "AuthController.Refresh:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			var dtoRequest dto.RefreshRequest
+			if !readRequest(responseWriter, request, handlerName, &dtoRequest, "dto.RefreshRequest") {
+				return
+			}
+
+			authTokenModel, err := authController.authService.Refresh(request.Context(), dtoRequest.RefreshToken)
+			if err != nil {
+				switch {
+				case errors.Is(err, auth.ErrRefreshTokenReused):
+					http_response.WriteError(responseWriter, request, handlerName, http.StatusUnauthorized, err, messages.AuthRefreshTokenReused)
+				case errors.Is(err, auth.ErrInvalidRefreshToken):
+					http_response.WriteError(responseWriter, request, handlerName, http.StatusUnauthorized, err, messages.AuthInvalidRefreshToken)
+				default:
+					http_response.WriteError(responseWriter, request, handlerName, http.StatusInternalServerError, err, messages.AuthRefreshFailed)
+				}
+				return
+			}
+
+			if !writeTokens(responseWriter, request, handlerName, authController.authMapper.ToDto(*authTokenModel)) {
+				return
+			}
+
+			log.Println(handlerName, request.URL.Path, "from", request.Host, "token refreshed")
+		},
+	)
+}
+
+// Logout implements auth.AuthController.
+func (authController *authControllerImpl) Logout() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "AuthController.Logout:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			payload, err := middleware.GetPayload(request)
+			if err != nil {
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusUnauthorized, err, messages.AuthPayloadFailed)
+				return
+			}
+
+			data, err := io.ReadAll(request.Body)
+			if err != nil {
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusInternalServerError, err, messages.RequestReadBodyFailed)
+				return
+			}
+
+			// Тело запроса необязательно: refresh-токен передается, если нужно завершить всю сессию
+			var dtoRequest dto.LogoutRequest
+			if len(data) != 0 && !decodeRequest(responseWriter, request, handlerName, data, &dtoRequest, "dto.LogoutRequest") {
+				return
+			}
+
+			if err := authController.authService.Logout(request.Context(), payload, dtoRequest.RefreshToken); err != nil {
+				if errors.Is(err, auth.ErrInvalidRefreshToken) {
+					http_response.WriteError(responseWriter, request, handlerName, http.StatusBadRequest, err, messages.AuthInvalidRefreshToken)
+					return
+				}
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusInternalServerError, err, messages.AuthRevokeFailed)
+				return
+			}
+
+			responseWriter.WriteHeader(http.StatusNoContent)
+			log.Printf("AuthController.Logout(%s) is success\n", payload.Subject)
+		},
+	)
+}
+
+// Revoke implements auth.AuthController.
+func (authController *authControllerImpl) Revoke() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "AuthController.Revoke:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			var dtoRequest dto.RevokeRequest
+			if !readRequest(responseWriter, request, handlerName, &dtoRequest, "dto.RevokeRequest") {
+				return
+			}
+
+			if err := authController.authService.Revoke(request.Context(), dtoRequest.Token); err != nil {
+				if errors.Is(err, auth.ErrInvalidToken) {
+					http_response.WriteError(responseWriter, request, handlerName, http.StatusBadRequest, err, messages.AuthInvalidToken)
+					return
+				}
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusInternalServerError, err, messages.AuthRevokeFailed)
+				return
+			}
+
+			responseWriter.WriteHeader(http.StatusNoContent)
+			log.Println(handlerName, request.URL.Path, "from", request.Host, "is success")
+		},
+	)
+}
+
+// JWKS implements auth.AuthController.
+func (authController *authControllerImpl) JWKS() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "AuthController.JWKS:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			data, err := json.Marshal(authController.authService.JWKS())
+			if err != nil {
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusInternalServerError, err, messages.AuthEncodeFailed, "jwt_metadata.JWKSet")
+				return
+			}
+
+			responseWriter.Header().Set("Content-Type", "application/json")
+			responseWriter.Header().Set("Cache-Control", "public, max-age=300")
+			responseWriter.WriteHeader(http.StatusOK)
+			if _, err := responseWriter.Write(append(data, '\n')); err != nil {
+				log.Println(handlerName, request.URL.Path, "from", request.Host, err)
+			}
+		},
+	)
+}
+
+// readRequest читает JSON из тела запроса в dtoRequest и проверяет его.
+// При ошибке ответ уже отправлен и возвращается false.
+func readRequest(responseWriter http.ResponseWriter, request *http.Request, handlerName string, dtoRequest any, dtoName string) bool {
+	data, ok := http_response.ReadBody(responseWriter, request, handlerName)
+	if !ok {
+		return false
+	}
+	return decodeRequest(responseWriter, request, handlerName, data, dtoRequest, dtoName)
+}
+
+// decodeRequest разбирает JSON data в dtoRequest и проверяет его.
+// При ошибке ответ уже отправлен и возвращается false.
+func decodeRequest(responseWriter http.ResponseWriter, request *http.Request, handlerName string, data []byte, dtoRequest any, dtoName string) bool {
+	if err := json.Unmarshal(data, dtoRequest); err != nil {
+		http_response.WriteError(responseWriter, request, handlerName, http.StatusBadRequest, err, messages.RequestInvalidJSON, dtoName)
+		return false
+	}
+
+	if err := validator.New().Struct(dtoRequest); err != nil {
+		http_response.WriteError(responseWriter, request, handlerName, http.StatusBadRequest, err, messages.RequestInvalid, dtoName)
+		return false
+	}
+	return true
+}
+
+// writeTokens отправляет выданные токены, запрещая их кэширование. Токены не записываются в журнал.
+// Если ответ не удалось подготовить, отправляется ошибка и возвращается false.
+func writeTokens(responseWriter http.ResponseWriter, request *http.Request, handlerName string, dtoResponse *dto.TokenResponse) bool {
+	data, err := json.Marshal(dtoResponse)
+	if err != nil {
+		http_response.WriteError(responseWriter, request, handlerName, http.StatusInternalServerError, err, messages.AuthEncodeFailed, "dto.TokenResponse")
+		return false
+	}
+
+	responseWriter.Header().Set("Content-Type", "application/json")
+	responseWriter.Header().Set("Cache-Control", "no-store")
+	responseWriter.WriteHeader(http.StatusOK)
+	if _, err := responseWriter.Write(append(data, '\n')); err != nil {
+		log.Println(handlerName, request.URL.Path, "from", request.Host, err)
+		return false
+	}
+	return true
+}
+
+func NewAuthController(authService auth.AuthService) auth.AuthController {
+	return &authControllerImpl{
+		authService: authService,
+	}
+}
@@ -0,0 +1,311 @@
+package http_controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"user-service/internal/auth"
+	"user-service/internal/auth/mock"
+	"user-service/internal/dto"
+	"user-service/internal/middleware"
+	"user-service/internal/model"
+	"user-service/pkg/jwt"
+	"user-service/pkg/jwt/jwt_metadata"
+	"user-service/pkg/problem"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/gorilla/mux"
+)
+
+const jsonLoginTemplate string = "{\"username\": \"%s\", \"password\": \"%s\"}"
+const jsonRefreshTemplate string = "{\"refresh_token\": \"%s\"}"
+const jsonRevokeTemplate string = "{\"token\": \"%s\"}"
+
+const (
+	mockUsername    string = "user1"
+	mockPassword    string = "12345678"
+	mockAccessToken string = "header.payload.signature"
+
+	mockRefreshToken       string = "valid-refresh-token"
+	mockRotatedToken       string = "rotated-refresh-token"
+	mockReusedRefreshToken string = "reused-refresh-token"
+)
+
+var mockService *mock.MockAuthService = &mock.MockAuthService{
+	LoginFunc: func(ctx context.Context, username, password string) (*model.AuthTokenModel, error) {
+		if username != mockUsername || password != mockPassword {
+			return nil, auth.ErrInvalidCredentials
+		}
+		return &model.AuthTokenModel{
+			AccessToken:      mockAccessToken,
+			ExpiresAt:        time.Now().Add(time.Minute * 15).Unix(),
+			RefreshToken:     mockRefreshToken,
+			RefreshExpiresAt: time.Now().Add(time.Hour * 720).Unix(),
+		}, nil
+	},
+	RefreshFunc: func(ctx context.Context, refreshToken string) (*model.AuthTokenModel, error) {
+		switch refreshToken {
+		case mockRefreshToken:
+			return &model.AuthTokenModel{
+				AccessToken:      mockAccessToken,
+				ExpiresAt:        time.Now().Add(time.Minute * 15).Unix(),
+				RefreshToken:     mockRotatedToken,
+				RefreshExpiresAt: time.Now().Add(time.Hour * 720).Unix(),
+			}, nil
+		case mockReusedRefreshToken:
+			return nil, auth.ErrRefreshTokenReused
+		default:
+			return nil, auth.ErrInvalidRefreshToken
+		}
+	},
+	LogoutFunc: func(ctx context.Context, payload *jwt_metadata.Payload, refreshToken string) error {
+		if refreshToken != "" && refreshToken != mockRefreshToken {
+			return auth.ErrInvalidRefreshToken
+		}
+		return nil
+	},
+	RevokeFunc: func(ctx context.Context, token string) error {
+		if token != userToken {
+			return auth.ErrInvalidToken
+		}
+		return nil
+	},
+	JWKSFunc: func() jwt_metadata.JWKSet {
+		return jwt_metadata.JWKSet{
+			Keys: []jwt_metadata.JWK{
+				{Kty: "OKP", Use: "sig", Alg: "EdDSA", Kid: "2025-01", Crv: "Ed25519", X: "public-key"},
+			},
+		}
+	},
+}
+
+var authController auth.AuthController = NewAuthController(mockService)
+var authRouter *mux.Router = mux.NewRouter()
+
+var adminToken string
+var userToken string
+
+func init() {
+	authRouter.Use(middleware.Localize)
+	authRouter = auth.SetupAuthRoutes(authRouter, authController)
+	jwtCoder := jwt.NewJWTCoder(middleware.Alg, middleware.Secret, middleware.Issuer, middleware.Audience, middleware.ExpirationTimeDuration)
+	adminToken = encodeToken(jwtCoder, "1", "admin")
+	userToken = encodeToken(jwtCoder, "2", "user")
+}
+
+func encodeToken(jwtCoder *jwt.JWTCoder, subject, role string) string {
+	strToken, err := jwtCoder.Encode(*jwtCoder.NewToken(subject, []string{role}))
+	if err != nil {
+		panic(err)
+	}
+	return *strToken
+}
+
+func TestLoginHandler(t *testing.T) {
+	t.Run("Empty Body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", nil)
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("JSON to LoginRequest", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader("{}"))
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Wrong password", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonLoginTemplate, mockUsername, "wrong-password")
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(jsonBody))
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Accept-Language en", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonLoginTemplate, mockUsername, "wrong-password")
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(jsonBody))
+		req.Header.Add("Accept-Language", "en")
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusUnauthorized)
+		assert.Equal(t, res.Header().Get("Content-Type"), problem.ContentType)
+
+		var problemDetails problem.Problem
+		if err := json.NewDecoder(res.Body).Decode(&problemDetails); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, problemDetails.Detail, "Invalid username or password!")
+	})
+
+	t.Run("Unknown username", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonLoginTemplate, "unknown", mockPassword)
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(jsonBody))
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Token issued", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonLoginTemplate, mockUsername, mockPassword)
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(jsonBody))
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponse dto.TokenResponse
+		err := json.NewDecoder(res.Body).Decode(&dtoResponse)
+		if err != nil {
+			t.Fail()
+		}
+
+		assert.Equal(t, dtoResponse.AccessToken, mockAccessToken)
+		assert.Equal(t, dtoResponse.TokenType, "Bearer")
+		assert.Equal(t, dtoResponse.ExpiresIn > 0, true)
+		assert.Equal(t, dtoResponse.RefreshToken, mockRefreshToken)
+	})
+}
+
+func TestRefreshHandler(t *testing.T) {
+	t.Run("Empty Body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("JSON to RefreshRequest", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader("{}"))
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRefreshTemplate, "unknown")
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(jsonBody))
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Reused token", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRefreshTemplate, mockReusedRefreshToken)
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(jsonBody))
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Token rotated", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRefreshTemplate, mockRefreshToken)
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(jsonBody))
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponse dto.TokenResponse
+		err := json.NewDecoder(res.Body).Decode(&dtoResponse)
+		if err != nil {
+			t.Fail()
+		}
+
+		assert.Equal(t, dtoResponse.AccessToken, mockAccessToken)
+		assert.Equal(t, dtoResponse.RefreshToken, mockRotatedToken)
+	})
+}
+
+func TestLogoutHandler(t *testing.T) {
+	t.Run("No token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Foreign refresh token", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRefreshTemplate, "unknown")
+		req := httptest.NewRequest(http.MethodPost, "/auth/logout", strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+userToken)
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Access token revoked", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+		req.Header.Add("Authorization", "Bearer "+userToken)
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNoContent)
+	})
+
+	t.Run("Session revoked", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRefreshTemplate, mockRefreshToken)
+		req := httptest.NewRequest(http.MethodPost, "/auth/logout", strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+userToken)
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNoContent)
+	})
+}
+
+func TestRevokeHandler(t *testing.T) {
+	t.Run("No token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/auth/revoke", nil)
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Not admin", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRevokeTemplate, userToken)
+		req := httptest.NewRequest(http.MethodPost, "/auth/revoke", strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+userToken)
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusForbidden)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRevokeTemplate, "invalid")
+		req := httptest.NewRequest(http.MethodPost, "/auth/revoke", strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+adminToken)
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Token revoked", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRevokeTemplate, userToken)
+		req := httptest.NewRequest(http.MethodPost, "/auth/revoke", strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+adminToken)
+		res := httptest.NewRecorder()
+		authRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNoContent)
+	})
+}
+
+func TestJWKSHandler(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	res := httptest.NewRecorder()
+	authRouter.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, http.StatusOK)
+
+	var jwkSet jwt_metadata.JWKSet
+	err := json.NewDecoder(res.Body).Decode(&jwkSet)
+	if err != nil {
+		t.Fail()
+	}
+
+	assert.Equal(t, len(jwkSet.Keys), 1)
+	assert.Equal(t, jwkSet.Keys[0].Kid, "2025-01")
+}
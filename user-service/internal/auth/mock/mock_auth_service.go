@@ -0,0 +1,47 @@
+package mock
+
+import (
+	"context"
+	"user-service/internal/model"
+	"user-service/pkg/jwt/jwt_metadata"
+)
+
+// Создаем мок-реализацию
+type MockAuthService struct {
+	LoginFunc              func(ctx context.Context, username, password string) (*model.AuthTokenModel, error)
+	RefreshFunc            func(ctx context.Context, refreshToken string) (*model.AuthTokenModel, error)
+	LogoutFunc             func(ctx context.Context, payload *jwt_metadata.Payload, refreshToken string) error
+	RevokeFunc             func(ctx context.Context, token string) error
+	PruneRevokedTokensFunc func(ctx context.Context) (int64, error)
+	JWKSFunc               func() jwt_metadata.JWKSet
+}
+
+// Login implements auth.AuthService.
+func (m *MockAuthService) Login(ctx context.Context, username, password string) (*model.AuthTokenModel, error) {
+	return m.LoginFunc(ctx, username, password)
+}
+
+// Refresh implements auth.AuthService.
+func (m *MockAuthService) Refresh(ctx context.Context, refreshToken string) (*model.AuthTokenModel, error) {
+	return m.RefreshFunc(ctx, refreshToken)
+}
+
+// Logout implements auth.AuthService.
+func (m *MockAuthService) Logout(ctx context.Context, payload *jwt_metadata.Payload, refreshToken string) error {
+	return m.LogoutFunc(ctx, payload, refreshToken)
+}
+
+// Revoke implements auth.AuthService.
+func (m *MockAuthService) Revoke(ctx context.Context, token string) error {
+	return m.RevokeFunc(ctx, token)
+}
+
+// PruneRevokedTokens implements auth.AuthService.
+func (m *MockAuthService) PruneRevokedTokens(ctx context.Context) (int64, error) {
+	return m.PruneRevokedTokensFunc(ctx)
+}
+
+// JWKS implements auth.AuthService.
+func (m *MockAuthService) JWKS() jwt_metadata.JWKSet {
+	return m.JWKSFunc()
+}
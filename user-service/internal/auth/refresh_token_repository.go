@@ -0,0 +1,13 @@
+package auth
+
+import (
+	"context"
+	"user-service/internal/model"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, refreshTokenModel *model.RefreshTokenModel) error
+	GetByHash(ctx context.Context, tokenHash string) (*model.RefreshTokenModel, error)
+	MarkUsed(ctx context.Context, id int) (bool, error)
+	RevokeFamily(ctx context.Context, familyId string) error
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"user-service/internal/auth"
+	"user-service/internal/model"
+	"user-service/pkg/db"
+)
+
+type refreshTokenRepositoryImpl struct {
+	db db.DB
+}
+
+// Create implements auth.RefreshTokenRepository.
+func (refreshTokenRepository *refreshTokenRepositoryImpl) Create(ctx context.Context, refreshTokenModel *model.RefreshTokenModel) error {
+	ctx, cancel := refreshTokenRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := refreshTokenRepository.db.Querier(ctx)
+
+	return dbConnect.QueryRowContext(ctx,
+		"INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at) VALUES ($1, $2, $3, $4) returning id",
+		refreshTokenModel.UserId,
+		refreshTokenModel.TokenHash,
+		refreshTokenModel.FamilyId,
+		refreshTokenModel.ExpiresAt,
+	).Scan(&refreshTokenModel.Id)
+}
+
+// GetByHash implements auth.RefreshTokenRepository.
+func (refreshTokenRepository *refreshTokenRepositoryImpl) GetByHash(ctx context.Context, tokenHash string) (*model.RefreshTokenModel, error) {
+	ctx, cancel := refreshTokenRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := refreshTokenRepository.db.Querier(ctx)
+
+	row := dbConnect.QueryRowContext(ctx,
+		"SELECT id, user_id, token_hash, family_id, expires_at, used, revoked FROM refresh_tokens WHERE token_hash=$1",
+		tokenHash,
+	)
+	if err := row.Err(); err != nil {
+		return nil, err
+	}
+
+	var foundToken model.RefreshTokenModel
+	err := row.Scan(
+		&foundToken.Id,
+		&foundToken.UserId,
+		&foundToken.TokenHash,
+		&foundToken.FamilyId,
+		&foundToken.ExpiresAt,
+		&foundToken.Used,
+		&foundToken.Revoked,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &foundToken, nil
+}
+
+// MarkUsed implements auth.RefreshTokenRepository.
+// Возвращает false, если токен уже был использован или отозван другим запросом.
+func (refreshTokenRepository *refreshTokenRepositoryImpl) MarkUsed(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := refreshTokenRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := refreshTokenRepository.db.Querier(ctx)
+
+	result, err := dbConnect.ExecContext(ctx, "UPDATE refresh_tokens SET used=TRUE WHERE id=$1 AND used=FALSE AND revoked=FALSE", id)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// RevokeFamily implements auth.RefreshTokenRepository.
+func (refreshTokenRepository *refreshTokenRepositoryImpl) RevokeFamily(ctx context.Context, familyId string) error {
+	ctx, cancel := refreshTokenRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := refreshTokenRepository.db.Querier(ctx)
+
+	_, err := dbConnect.ExecContext(ctx, "UPDATE refresh_tokens SET revoked=TRUE WHERE family_id=$1", familyId)
+	return err
+}
+
+func NewRefreshTokenRepository(db db.DB) auth.RefreshTokenRepository {
+	return &refreshTokenRepositoryImpl{
+		db: db,
+	}
+}
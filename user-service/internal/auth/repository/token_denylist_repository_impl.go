@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"user-service/internal/auth"
+	"user-service/pkg/db"
+)
+
+type tokenDenylistRepositoryImpl struct {
+	db db.DB
+}
+
+// Add implements auth.TokenDenylistRepository.
+func (tokenDenylistRepository *tokenDenylistRepositoryImpl) Add(ctx context.Context, jwtId string, expiresAt time.Time) error {
+	ctx, cancel := tokenDenylistRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := tokenDenylistRepository.db.Querier(ctx)
+
+	_, err := dbConnect.ExecContext(ctx,
+		"INSERT INTO revoked_tokens (jti, expires_at) VALUES ($1, $2) ON CONFLICT (jti) DO NOTHING",
+		jwtId,
+		expiresAt,
+	)
+	return err
+}
+
+// ExistByJWTID implements auth.TokenDenylistRepository.
+func (tokenDenylistRepository *tokenDenylistRepositoryImpl) ExistByJWTID(ctx context.Context, jwtId string) (bool, error) {
+	ctx, cancel := tokenDenylistRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := tokenDenylistRepository.db.Querier(ctx)
+
+	var count int
+	if err := dbConnect.QueryRowContext(ctx, "SELECT COUNT(*) FROM revoked_tokens WHERE jti=$1", jwtId).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// DeleteExpired implements auth.TokenDenylistRepository.
+func (tokenDenylistRepository *tokenDenylistRepositoryImpl) DeleteExpired(ctx context.Context, leeway time.Duration) (int64, error) {
+	ctx, cancel := tokenDenylistRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := tokenDenylistRepository.db.Querier(ctx)
+
+	result, err := dbConnect.ExecContext(ctx,
+		"DELETE FROM revoked_tokens WHERE expires_at < NOW() - $1 * INTERVAL '1 second'",
+		leeway.Seconds(),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func NewTokenDenylistRepository(db db.DB) auth.TokenDenylistRepository {
+	return &tokenDenylistRepositoryImpl{
+		db: db,
+	}
+}
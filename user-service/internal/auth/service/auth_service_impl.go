@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+	"user-service/internal/auth"
+	"user-service/internal/model"
+	"user-service/internal/rbac"
+	"user-service/internal/user"
+	"user-service/pkg/db"
+	"user-service/pkg/jwt"
+	"user-service/pkg/jwt/jwt_metadata"
+	"user-service/pkg/password"
+)
+
+const (
+	refreshTokenLength int = 32 // Длина refresh-токена в байтах
+	familyIdLength     int = 16 // Длина идентификатора семейства refresh-токенов в байтах
+)
+
+type authServiceImpl struct {
+	userRepository         user.UserRepository
+	userRoleRepository     rbac.UserRoleRepository
+	refreshTokenRepository auth.RefreshTokenRepository
+	denylistRepository     auth.TokenDenylistRepository
+	unitOfWork             db.UnitOfWork
+	passwordHasher         *password.PasswordHasher
+	jwtCoder               *jwt.JWTCoder
+	refreshTokenTTL        time.Duration
+	// dummyPasswordHash проверяется, когда пользователь не найден,
+	// чтобы время ответа не зависело от существования пользователя
+	dummyPasswordHash string
+}
+
+// Login implements auth.AuthService.
+func (authService *authServiceImpl) Login(ctx context.Context, username, password string) (*model.AuthTokenModel, error) {
+	userModel, err := authService.userRepository.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			authService.passwordHasher.Verify(password, authService.dummyPasswordHash)
+			return nil, auth.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	isValid, needsRehash, err := authService.passwordHasher.Verify(password, userModel.Password_Hash)
+	if err != nil {
+		log.Println("AuthService.Login: ошибка проверки хеша пароля пользователя с 'id'=", userModel.Id, err)
+		return nil, auth.ErrInvalidCredentials
+	}
+	if !isValid {
+		return nil, auth.ErrInvalidCredentials
+	}
+	if needsRehash {
+		authService.rehashPassword(ctx, userModel.Id, password)
+	}
+
+	familyId, err := generateRandomHex(familyIdLength)
+	if err != nil {
+		return nil, err
+	}
+
+	return authService.issueTokens(ctx, userModel, familyId)
+}
+
+// Refresh implements auth.AuthService.
+func (authService *authServiceImpl) Refresh(ctx context.Context, refreshToken string) (*model.AuthTokenModel, error) {
+	refreshTokenModel, err := authService.refreshTokenRepository.GetByHash(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, auth.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if refreshTokenModel.Revoked {
+		return nil, auth.ErrInvalidRefreshToken
+	}
+	if refreshTokenModel.Used {
+		return nil, authService.revokeFamily(ctx, refreshTokenModel.FamilyId)
+	}
+	if time.Now().After(refreshTokenModel.ExpiresAt) {
+		return nil, auth.ErrInvalidRefreshToken
+	}
+
+	// Пометка и выпуск новой пары выполняются в одной транзакции: если выпуск не удался, пометка
+	// откатывается и клиент может повторить запрос, не вызвав отзыв семейства как при повторном использовании
+	var authTokenModel *model.AuthTokenModel
+	err = authService.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		// Пометка выполняется атомарно: из двух одновременных запросов с одним токеном успешен только один
+		isMarked, err := authService.refreshTokenRepository.MarkUsed(ctx, refreshTokenModel.Id)
+		if err != nil {
+			return err
+		}
+		if !isMarked {
+			return auth.ErrRefreshTokenReused
+		}
+
+		userModel, err := authService.userRepository.GetOne(ctx, refreshTokenModel.UserId)
+		if err != nil {
+			if errors.Is(err, user.ErrUserNotFound) {
+				return auth.ErrInvalidRefreshToken
+			}
+			return err
+		}
+
+		authTokenModel, err = authService.issueTokens(ctx, userModel, refreshTokenModel.FamilyId)
+		return err
+	})
+	if errors.Is(err, auth.ErrRefreshTokenReused) {
+		// Семейство отзывается вне откаченной транзакции, иначе отзыв тоже был бы отменен
+		return nil, authService.revokeFamily(ctx, refreshTokenModel.FamilyId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return authTokenModel, nil
+}
+
+// Logout implements auth.AuthService.
+func (authService *authServiceImpl) Logout(ctx context.Context, payload *jwt_metadata.Payload, refreshToken string) error {
+	if err := authService.denylistRepository.Add(ctx, payload.JWTID, time.Unix(payload.ExpirationTime, 0)); err != nil {
+		return err
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	refreshTokenModel, err := authService.refreshTokenRepository.GetByHash(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return auth.ErrInvalidRefreshToken
+		}
+		return err
+	}
+	if strconv.Itoa(refreshTokenModel.UserId) != payload.Subject {
+		return auth.ErrInvalidRefreshToken
+	}
+	return authService.refreshTokenRepository.RevokeFamily(ctx, refreshTokenModel.FamilyId)
+}
+
+// Revoke implements auth.AuthService.
+func (authService *authServiceImpl) Revoke(ctx context.Context, token string) error {
+	parsedToken, err := authService.jwtCoder.Parse(token)
+	if err != nil {
+		return errors.Join(auth.ErrInvalidToken, err)
+	}
+	if parsedToken.Payload.JWTID == "" {
+		return auth.ErrInvalidToken
+	}
+	return authService.denylistRepository.Add(ctx,
+		parsedToken.Payload.JWTID,
+		time.Unix(parsedToken.Payload.ExpirationTime, 0),
+	)
+}
+
+// PruneRevokedTokens implements auth.AuthService.
+func (authService *authServiceImpl) PruneRevokedTokens(ctx context.Context) (int64, error) {
+	// Токен принимается еще leeway после exp, и до этого момента его отзыв должен действовать
+	return authService.denylistRepository.DeleteExpired(ctx, authService.jwtCoder.Leeway())
+}
+
+// JWKS implements auth.AuthService.
+func (authService *authServiceImpl) JWKS() jwt_metadata.JWKSet {
+	return authService.jwtCoder.JWKS()
+}
+
+// issueTokens выпускает access-токен и новый refresh-токен в рамках семейства familyId.
+// Роли и права читаются из базы данных при каждом выпуске, поэтому их изменение
+// вступает в силу не позднее следующего обновления токена.
+func (authService *authServiceImpl) issueTokens(ctx context.Context, userModel *model.UserModel, familyId string) (*model.AuthTokenModel, error) {
+	roles, err := authService.userRoleRepository.GetRoles(ctx, userModel.Id)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := authService.userRoleRepository.GetPermissions(ctx, userModel.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	token := authService.jwtCoder.NewToken(strconv.Itoa(userModel.Id), roles, permissions...)
+	token.Payload.Tenant = userModel.Tenant
+	accessToken, err := authService.jwtCoder.Encode(*token)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := generateRandomBase64(refreshTokenLength)
+	if err != nil {
+		return nil, err
+	}
+	refreshTokenModel := &model.RefreshTokenModel{
+		UserId:    userModel.Id,
+		TokenHash: hashRefreshToken(refreshToken),
+		FamilyId:  familyId,
+		ExpiresAt: time.Now().Add(authService.refreshTokenTTL),
+	}
+	if err := authService.refreshTokenRepository.Create(ctx, refreshTokenModel); err != nil {
+		return nil, err
+	}
+
+	return &model.AuthTokenModel{
+		AccessToken:      *accessToken,
+		ExpiresAt:        token.Payload.ExpirationTime,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshTokenModel.ExpiresAt.Unix(),
+	}, nil
+}
+
+// rehashPassword заменяет хеш пароля, созданный устаревшим алгоритмом или с устаревшими параметрами.
+// Ошибка не прерывает вход: хеш будет заменен при следующей успешной аутентификации.
+func (authService *authServiceImpl) rehashPassword(ctx context.Context, userId int, password string) {
+	passwordHash, err := authService.passwordHasher.Hash(password)
+	if err == nil {
+		err = authService.userRepository.UpdatePasswordHash(ctx, userId, passwordHash)
+	}
+	if err != nil {
+		log.Println("AuthService.Login: ошибка перехеширования пароля пользователя с 'id'=", userId, err)
+	}
+}
+
+// revokeFamily отзывает все семейство refresh-токенов после обнаружения повторного использования
+func (authService *authServiceImpl) revokeFamily(ctx context.Context, familyId string) error {
+	if err := authService.refreshTokenRepository.RevokeFamily(ctx, familyId); err != nil {
+		return errors.Join(auth.ErrRefreshTokenReused, err)
+	}
+	return auth.ErrRefreshTokenReused
+}
+
+// hashRefreshToken возвращает хеш refresh-токена, под которым он хранится в базе данных
+func hashRefreshToken(refreshToken string) string {
+	tokenHash := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(tokenHash[:])
+}
+
+func generateRandomBytes(length int) ([]byte, error) {
+	data := make([]byte, length)
+	if _, err := rand.Read(data); err != nil {
+		return nil, fmt.Errorf("ошибка генерации случайных данных: %w", err)
+	}
+	return data, nil
+}
+
+func generateRandomBase64(length int) (string, error) {
+	data, err := generateRandomBytes(length)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func generateRandomHex(length int) (string, error) {
+	data, err := generateRandomBytes(length)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+func NewAuthService(
+	userRepository user.UserRepository,
+	userRoleRepository rbac.UserRoleRepository,
+	refreshTokenRepository auth.RefreshTokenRepository,
+	denylistRepository auth.TokenDenylistRepository,
+	unitOfWork db.UnitOfWork,
+	passwordHasher *password.PasswordHasher,
+	jwtCoder *jwt.JWTCoder,
+	refreshTokenTTL time.Duration,
+) (auth.AuthService, error) {
+	dummyPasswordHash, err := passwordHasher.Hash("")
+	if err != nil {
+		return nil, err
+	}
+	return &authServiceImpl{
+		userRepository:         userRepository,
+		userRoleRepository:     userRoleRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		denylistRepository:     denylistRepository,
+		unitOfWork:             unitOfWork,
+		passwordHasher:         passwordHasher,
+		jwtCoder:               jwtCoder,
+		refreshTokenTTL:        refreshTokenTTL,
+		dummyPasswordHash:      dummyPasswordHash,
+	}, nil
+}
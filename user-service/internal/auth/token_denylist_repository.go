@@ -0,0 +1,14 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+type TokenDenylistRepository interface {
+	Add(ctx context.Context, jwtId string, expiresAt time.Time) error
+	ExistByJWTID(ctx context.Context, jwtId string) (bool, error)
+	// DeleteExpired удаляет записи токенов, истекших более leeway назад. Пока не прошло leeway
+	// после exp, токен еще принимается при проверке, поэтому его запись должна сохраняться.
+	DeleteExpired(ctx context.Context, leeway time.Duration) (int64, error)
+}
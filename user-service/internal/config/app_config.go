@@ -11,6 +11,9 @@ import (
 type AppConfig struct {
 	server   ServerConfig
 	database DatabaseConfig
+	auth     AuthConfig
+	jwt      JWTConfig
+	password PasswordConfig
 }
 
 func (config *AppConfig) GetServerConfig() ServerConfig {
@@ -21,10 +24,25 @@ func (config *AppConfig) GetDatabaseConfig() DatabaseConfig {
 	return config.database
 }
 
+func (config *AppConfig) GetAuthConfig() AuthConfig {
+	return config.auth
+}
+
+func (config *AppConfig) GetJWTConfig() JWTConfig {
+	return config.jwt
+}
+
+func (config *AppConfig) GetPasswordConfig() PasswordConfig {
+	return config.password
+}
+
 func newAppConfig(yml *yml_config.YMLAppConfig) *AppConfig {
 	return &AppConfig{
 		server:   newServerConfig(&yml.Server),
 		database: newDatabaseConfig(&yml.Database),
+		auth:     newAuthConfig(&yml.Auth),
+		jwt:      newJWTConfig(&yml.JWT),
+		password: newPasswordConfig(&yml.Password),
 	}
 }
 
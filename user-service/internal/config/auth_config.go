@@ -0,0 +1,28 @@
+package config
+
+import (
+	"time"
+	"user-service/internal/config/yml_config"
+)
+
+type AuthConfig struct {
+	refreshTokenTTL       time.Duration
+	denylistPruneInterval time.Duration
+}
+
+// GetRefreshTokenTTL возвращает время жизни refresh-токена
+func (config *AuthConfig) GetRefreshTokenTTL() time.Duration {
+	return config.refreshTokenTTL
+}
+
+// GetDenylistPruneInterval возвращает период удаления истекших записей из списка отозванных токенов
+func (config *AuthConfig) GetDenylistPruneInterval() time.Duration {
+	return config.denylistPruneInterval
+}
+
+func newAuthConfig(yml *yml_config.YMLAuthConfig) AuthConfig {
+	return AuthConfig{
+		refreshTokenTTL:       yml.RefreshTokenTTL,
+		denylistPruneInterval: yml.DenylistPruneInterval,
+	}
+}
@@ -1,14 +1,26 @@
 package config
 
-import "user-service/internal/config/yml_config"
+import (
+	"time"
+	"user-service/internal/config/yml_config"
+)
 
 type DatabaseConfig struct {
-	host     string
-	port     int
-	user     string
-	password string
-	dbname   string
-	sslmode  string
+	host                   string
+	port                   int
+	user                   string
+	password               string
+	dbname                 string
+	sslmode                string
+	maxOpenConns           int
+	maxIdleConns           int
+	connMaxLifetime        time.Duration
+	connMaxIdleTime        time.Duration
+	pingOnStart            bool
+	pingTimeout            time.Duration
+	queryTimeout           time.Duration
+	migrateOnStart         bool
+	countEstimateThreshold int
 }
 
 // GetHost возвращает хост базы данных
@@ -41,13 +53,68 @@ func (config *DatabaseConfig) GetSSLMode() string {
 	return config.sslmode
 }
 
+// GetMaxOpenConns возвращает максимальное число открытых соединений (0 — без ограничения)
+func (config *DatabaseConfig) GetMaxOpenConns() int {
+	return config.maxOpenConns
+}
+
+// GetMaxIdleConns возвращает максимальное число простаивающих соединений в пуле
+func (config *DatabaseConfig) GetMaxIdleConns() int {
+	return config.maxIdleConns
+}
+
+// GetConnMaxLifetime возвращает максимальное время жизни соединения (0 — без ограничения)
+func (config *DatabaseConfig) GetConnMaxLifetime() time.Duration {
+	return config.connMaxLifetime
+}
+
+// GetConnMaxIdleTime возвращает максимальное время простоя соединения (0 — без ограничения)
+func (config *DatabaseConfig) GetConnMaxIdleTime() time.Duration {
+	return config.connMaxIdleTime
+}
+
+// GetPingOnStart возвращает признак проверки доступности базы данных при запуске
+func (config *DatabaseConfig) GetPingOnStart() bool {
+	return config.pingOnStart
+}
+
+// GetPingTimeout возвращает время ожидания проверки доступности базы данных
+func (config *DatabaseConfig) GetPingTimeout() time.Duration {
+	return config.pingTimeout
+}
+
+// GetQueryTimeout возвращает предельное время выполнения одного запроса (0 — без ограничения)
+func (config *DatabaseConfig) GetQueryTimeout() time.Duration {
+	return config.queryTimeout
+}
+
+// GetMigrateOnStart возвращает признак применения миграций схемы при запуске
+func (config *DatabaseConfig) GetMigrateOnStart() bool {
+	return config.migrateOnStart
+}
+
+// GetCountEstimateThreshold возвращает число строк, начиная с которого общее число записей
+// берется из статистики планировщика вместо точного подсчета (0 — всегда считать точно)
+func (config *DatabaseConfig) GetCountEstimateThreshold() int {
+	return config.countEstimateThreshold
+}
+
 func newDatabaseConfig(yml *yml_config.YMLDatabaseConfig) DatabaseConfig {
 	return DatabaseConfig{
-		host:     yml.Host,
-		port:     yml.Port,
-		user:     yml.User,
-		password: yml.Password,
-		dbname:   yml.DBName,
-		sslmode:  yml.SSLMode,
+		host:                   yml.Host,
+		port:                   yml.Port,
+		user:                   yml.User,
+		password:               yml.Password,
+		dbname:                 yml.DBName,
+		sslmode:                yml.SSLMode,
+		maxOpenConns:           yml.MaxOpenConns,
+		maxIdleConns:           yml.MaxIdleConns,
+		connMaxLifetime:        yml.ConnMaxLifetime,
+		connMaxIdleTime:        yml.ConnMaxIdleTime,
+		pingOnStart:            yml.PingOnStart,
+		pingTimeout:            yml.PingTimeout,
+		queryTimeout:           yml.QueryTimeout,
+		migrateOnStart:         yml.MigrateOnStart,
+		countEstimateThreshold: yml.CountEstimateThreshold,
 	}
 }
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"time"
+	"user-service/internal/config/yml_config"
+)
+
+type JWTKeyConfig struct {
+	kid            string
+	alg            string
+	secret         string
+	privateKeyPath string
+	publicKeyPath  string
+}
+
+// GetKid возвращает идентификатор ключа
+func (config *JWTKeyConfig) GetKid() string {
+	return config.kid
+}
+
+// GetAlg возвращает алгоритм подписи токенов
+func (config *JWTKeyConfig) GetAlg() string {
+	return config.alg
+}
+
+// GetSecret возвращает секрет для HMAC-алгоритмов
+func (config *JWTKeyConfig) GetSecret() string {
+	return config.secret
+}
+
+// GetPrivateKeyPath возвращает путь к закрытому ключу в формате PEM
+func (config *JWTKeyConfig) GetPrivateKeyPath() string {
+	return config.privateKeyPath
+}
+
+// GetPublicKeyPath возвращает путь к открытому ключу в формате PEM
+func (config *JWTKeyConfig) GetPublicKeyPath() string {
+	return config.publicKeyPath
+}
+
+type JWTConfig struct {
+	activeKey   JWTKeyConfig
+	retiredKeys []JWTKeyConfig
+	issuer      string
+	audience    []string
+	expiration  time.Duration
+	leeway      time.Duration
+}
+
+// GetActiveKey возвращает ключ, которым подписываются новые токены
+func (config *JWTConfig) GetActiveKey() JWTKeyConfig {
+	return config.activeKey
+}
+
+// GetRetiredKeys возвращает ключи, выведенные из оборота и используемые только для проверки
+func (config *JWTConfig) GetRetiredKeys() []JWTKeyConfig {
+	return config.retiredKeys
+}
+
+// GetIssuer возвращает издателя токенов
+func (config *JWTConfig) GetIssuer() string {
+	return config.issuer
+}
+
+// GetAudience возвращает получателей, которым предназначаются токены
+func (config *JWTConfig) GetAudience() []string {
+	return config.audience
+}
+
+// GetExpiration возвращает время жизни access-токена
+func (config *JWTConfig) GetExpiration() time.Duration {
+	return config.expiration
+}
+
+// GetLeeway возвращает допустимое расхождение часов при проверке exp и nbf
+func (config *JWTConfig) GetLeeway() time.Duration {
+	return config.leeway
+}
+
+func newJWTKeyConfig(yml *yml_config.YMLJWTKeyConfig) JWTKeyConfig {
+	return JWTKeyConfig{
+		kid:            yml.Kid,
+		alg:            yml.Alg,
+		secret:         yml.Secret,
+		privateKeyPath: yml.PrivateKeyPath,
+		publicKeyPath:  yml.PublicKeyPath,
+	}
+}
+
+func newJWTConfig(yml *yml_config.YMLJWTConfig) JWTConfig {
+	retiredKeys := make([]JWTKeyConfig, 0, len(yml.RetiredKeys))
+	for index := range yml.RetiredKeys {
+		retiredKeys = append(retiredKeys, newJWTKeyConfig(&yml.RetiredKeys[index]))
+	}
+	return JWTConfig{
+		activeKey:   newJWTKeyConfig(&yml.YMLJWTKeyConfig),
+		retiredKeys: retiredKeys,
+		issuer:      yml.Issuer,
+		audience:    yml.Audience,
+		expiration:  yml.Expiration,
+		leeway:      yml.Leeway,
+	}
+}
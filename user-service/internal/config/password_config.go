@@ -0,0 +1,82 @@
+package config
+
+import "user-service/internal/config/yml_config"
+
+type PasswordConfig struct {
+	algorithm string
+	argon2id  Argon2idConfig
+	bcrypt    BcryptConfig
+}
+
+// GetAlgorithm возвращает алгоритм хеширования новых паролей: argon2id или bcrypt
+func (config *PasswordConfig) GetAlgorithm() string {
+	return config.algorithm
+}
+
+// GetArgon2idConfig возвращает параметры Argon2id
+func (config *PasswordConfig) GetArgon2idConfig() Argon2idConfig {
+	return config.argon2id
+}
+
+// GetBcryptConfig возвращает параметры bcrypt
+func (config *PasswordConfig) GetBcryptConfig() BcryptConfig {
+	return config.bcrypt
+}
+
+type Argon2idConfig struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	saltLength  uint32
+	keyLength   uint32
+}
+
+// GetMemory возвращает объем памяти в КиБ
+func (config *Argon2idConfig) GetMemory() uint32 {
+	return config.memory
+}
+
+// GetIterations возвращает число проходов
+func (config *Argon2idConfig) GetIterations() uint32 {
+	return config.iterations
+}
+
+// GetParallelism возвращает число потоков
+func (config *Argon2idConfig) GetParallelism() uint8 {
+	return config.parallelism
+}
+
+// GetSaltLength возвращает длину соли в байтах
+func (config *Argon2idConfig) GetSaltLength() uint32 {
+	return config.saltLength
+}
+
+// GetKeyLength возвращает длину хеша в байтах
+func (config *Argon2idConfig) GetKeyLength() uint32 {
+	return config.keyLength
+}
+
+type BcryptConfig struct {
+	cost int
+}
+
+// GetCost возвращает стоимость bcrypt
+func (config *BcryptConfig) GetCost() int {
+	return config.cost
+}
+
+func newPasswordConfig(yml *yml_config.YMLPasswordConfig) PasswordConfig {
+	return PasswordConfig{
+		algorithm: yml.Algorithm,
+		argon2id: Argon2idConfig{
+			memory:      yml.Argon2id.Memory,
+			iterations:  yml.Argon2id.Iterations,
+			parallelism: yml.Argon2id.Parallelism,
+			saltLength:  yml.Argon2id.SaltLength,
+			keyLength:   yml.Argon2id.KeyLength,
+		},
+		bcrypt: BcryptConfig{
+			cost: yml.Bcrypt.Cost,
+		},
+	}
+}
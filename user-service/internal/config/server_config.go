@@ -3,8 +3,9 @@ package config
 import "user-service/internal/config/yml_config"
 
 type ServerConfig struct {
-	host string
-	port int
+	host            string
+	port            int
+	defaultLanguage string
 }
 
 // GetHost возвращает хост сервера
@@ -17,9 +18,16 @@ func (config *ServerConfig) GetPort() int {
 	return config.port
 }
 
+// GetDefaultLanguage возвращает язык сообщений, если клиент не передал
+// поддерживаемый язык в заголовке Accept-Language
+func (config *ServerConfig) GetDefaultLanguage() string {
+	return config.defaultLanguage
+}
+
 func newServerConfig(yml *yml_config.YMLServerConfig) ServerConfig {
 	return ServerConfig{
-		host: yml.Host,
-		port: yml.Port,
+		host:            yml.Host,
+		port:            yml.Port,
+		defaultLanguage: yml.DefaultLanguage,
 	}
 }
@@ -3,4 +3,7 @@ package yml_config
 type YMLAppConfig struct {
 	Server   YMLServerConfig   `yaml:"server"`
 	Database YMLDatabaseConfig `yaml:"database"`
+	Auth     YMLAuthConfig     `yaml:"auth"`
+	JWT      YMLJWTConfig      `yaml:"jwt"`
+	Password YMLPasswordConfig `yaml:"password"`
 }
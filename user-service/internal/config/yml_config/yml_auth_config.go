@@ -0,0 +1,8 @@
+package yml_config
+
+import "time"
+
+type YMLAuthConfig struct {
+	RefreshTokenTTL       time.Duration `yaml:"refresh_token_ttl" mapstructure:"refresh_token_ttl"`
+	DenylistPruneInterval time.Duration `yaml:"denylist_prune_interval" mapstructure:"denylist_prune_interval"`
+}
@@ -1,10 +1,21 @@
 package yml_config
 
+import "time"
+
 type YMLDatabaseConfig struct {
-	Host     string `yaml:"host"`
-	Port     int    `yaml:"port"`
-	User     string `yaml:"user"`
-	Password string `yaml:"password"`
-	DBName   string `yaml:"dbname"`
-	SSLMode  string `yaml:"sslmode"`
+	Host                   string        `yaml:"host"`
+	Port                   int           `yaml:"port"`
+	User                   string        `yaml:"user"`
+	Password               string        `yaml:"password"`
+	DBName                 string        `yaml:"dbname"`
+	SSLMode                string        `yaml:"sslmode"`
+	MaxOpenConns           int           `yaml:"max_open_conns" mapstructure:"max_open_conns"`
+	MaxIdleConns           int           `yaml:"max_idle_conns" mapstructure:"max_idle_conns"`
+	ConnMaxLifetime        time.Duration `yaml:"conn_max_lifetime" mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime        time.Duration `yaml:"conn_max_idle_time" mapstructure:"conn_max_idle_time"`
+	PingOnStart            bool          `yaml:"ping_on_start" mapstructure:"ping_on_start"`
+	PingTimeout            time.Duration `yaml:"ping_timeout" mapstructure:"ping_timeout"`
+	QueryTimeout           time.Duration `yaml:"query_timeout" mapstructure:"query_timeout"`
+	MigrateOnStart         bool          `yaml:"migrate_on_start" mapstructure:"migrate_on_start"`
+	CountEstimateThreshold int           `yaml:"count_estimate_threshold" mapstructure:"count_estimate_threshold"`
 }
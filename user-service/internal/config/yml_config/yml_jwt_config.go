@@ -0,0 +1,20 @@
+package yml_config
+
+import "time"
+
+type YMLJWTKeyConfig struct {
+	Kid            string `yaml:"kid"`
+	Alg            string `yaml:"alg"`
+	Secret         string `yaml:"secret"`
+	PrivateKeyPath string `yaml:"private_key_path" mapstructure:"private_key_path"`
+	PublicKeyPath  string `yaml:"public_key_path" mapstructure:"public_key_path"`
+}
+
+type YMLJWTConfig struct {
+	YMLJWTKeyConfig `yaml:",inline" mapstructure:",squash"`
+	RetiredKeys     []YMLJWTKeyConfig `yaml:"retired_keys" mapstructure:"retired_keys"`
+	Issuer          string            `yaml:"issuer"`
+	Audience        []string          `yaml:"audience"`
+	Expiration      time.Duration     `yaml:"expiration"`
+	Leeway          time.Duration     `yaml:"leeway"`
+}
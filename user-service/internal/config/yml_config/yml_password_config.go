@@ -0,0 +1,19 @@
+package yml_config
+
+type YMLPasswordConfig struct {
+	Algorithm string            `yaml:"algorithm"`
+	Argon2id  YMLArgon2idConfig `yaml:"argon2id"`
+	Bcrypt    YMLBcryptConfig   `yaml:"bcrypt"`
+}
+
+type YMLArgon2idConfig struct {
+	Memory      uint32 `yaml:"memory"`
+	Iterations  uint32 `yaml:"iterations"`
+	Parallelism uint8  `yaml:"parallelism"`
+	SaltLength  uint32 `yaml:"salt_length" mapstructure:"salt_length"`
+	KeyLength   uint32 `yaml:"key_length" mapstructure:"key_length"`
+}
+
+type YMLBcryptConfig struct {
+	Cost int `yaml:"cost"`
+}
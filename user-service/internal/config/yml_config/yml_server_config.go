@@ -1,6 +1,7 @@
 package yml_config
 
 type YMLServerConfig struct {
-	Host string `yaml:"host"`
-	Port int    `yaml:"port"`
+	Host            string `yaml:"host"`
+	Port            int    `yaml:"port"`
+	DefaultLanguage string `yaml:"default_language" mapstructure:"default_language"`
 }
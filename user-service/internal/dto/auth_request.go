@@ -0,0 +1,18 @@
+package dto
+
+type LoginRequest struct {
+	Username string `json:"username" validate:"required,max=32"`
+	Password string `json:"password" validate:"required,max=32"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required,max=64"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"max=64"`
+}
+
+type RevokeRequest struct {
+	Token string `json:"token" validate:"required"`
+}
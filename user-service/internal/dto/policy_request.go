@@ -0,0 +1,14 @@
+package dto
+
+type ExplainRequest struct {
+	Action     string          `json:"action" validate:"required,max=64"`
+	ResourceId int             `json:"resource_id" validate:"required,min=1"`
+	Subject    *ExplainSubject `json:"subject"` // Если не задан, используется субъект из токена запроса
+}
+
+type ExplainSubject struct {
+	Subject     string   `json:"sub"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+	Tenant      string   `json:"tenant"`
+}
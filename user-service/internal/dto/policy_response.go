@@ -0,0 +1,15 @@
+package dto
+
+type ExplainResponse struct {
+	Allowed bool                `json:"allowed"`
+	Rule    string              `json:"rule,omitempty"`
+	Reason  string              `json:"reason"`
+	Rules   []RuleTraceResponse `json:"rules"`
+}
+
+type RuleTraceResponse struct {
+	Rule    string   `json:"rule"`
+	Effect  string   `json:"effect"`
+	Matched bool     `json:"matched"`
+	Reasons []string `json:"reasons"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+type RoleRequest struct {
+	Name        string   `json:"name" validate:"required,min=2,max=32"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions" validate:"dive,required,max=64"`
+}
+
+type PermissionRequest struct {
+	Name        string `json:"name" validate:"required,min=2,max=64"`
+	Description string `json:"description" validate:"max=255"`
+}
@@ -4,3 +4,15 @@ type UserRequest struct {
 	Username string `json:"username" validate:"required,min=3,max=32"`
 	Password string `json:"password" validate:"required,min=8,max=32"`
 }
+
+// UserTenantRequest назначает пользователю организацию. Пустая строка означает, что организации нет.
+type UserTenantRequest struct {
+	Tenant string `json:"tenant" validate:"max=64"`
+}
+
+// UserPatchRequest — документ пользователя, к которому применяются изменения PATCH.
+// Отсутствующее поле означает, что значение не задано.
+type UserPatchRequest struct {
+	Username *string `json:"username,omitempty"`
+	Password *string `json:"password,omitempty"`
+}
@@ -3,4 +3,18 @@ package dto
 type UserResponse struct {
 	Id       int    `json:"id"`
 	Username string `json:"username"`
+	Tenant   string `json:"tenant,omitempty"`
+}
+
+// UserPageResponse — страница списка пользователей в конверте. Page не заполняется,
+// если страница выбрана курсором; Next и Prev — курсоры соседних страниц.
+type UserPageResponse struct {
+	Items          []UserResponse `json:"items"`
+	Total          int            `json:"total"`
+	TotalEstimated bool           `json:"totalEstimated,omitempty"`
+	Page           int            `json:"page,omitempty"`
+	Limit          int            `json:"limit"`
+	HasNext        bool           `json:"hasNext"`
+	Next           string         `json:"next,omitempty"`
+	Prev           string         `json:"prev,omitempty"`
 }
@@ -0,0 +1,111 @@
+package http_response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"user-service/internal/messages"
+	"user-service/pkg/http_helper"
+	"user-service/pkg/problem"
+
+	"github.com/gorilla/mux"
+)
+
+// WriteProblem отправляет описание ошибки в формате RFC 7807 и записывает ошибку в журнал
+func WriteProblem(responseWriter http.ResponseWriter, request *http.Request, handlerName string, problemDetails *problem.Problem, err error) {
+	problemDetails.Instance = request.URL.Path
+	if writeErr := problem.Write(responseWriter, problemDetails); writeErr != nil {
+		err = errors.Join(err, writeErr)
+	}
+	log.Println(
+		handlerName, request.URL.Path,
+		"from", request.Host,
+		problemDetails.Detail,
+		err,
+	)
+}
+
+// WriteError отправляет ошибку без собственного типа с сообщением messageKey на языке запроса
+func WriteError(responseWriter http.ResponseWriter, request *http.Request, handlerName string, status int, err error, messageKey string, messageArgs ...any) {
+	detail := messages.Get(request.Context(), messageKey, messageArgs...)
+	WriteProblem(responseWriter, request, handlerName, problem.New(status, detail), err)
+}
+
+// NewProblem описывает ошибку предметной области: заголовком служит сообщение titleKey,
+// а подробностями — сообщение detailKey о неудавшейся операции
+func NewProblem(ctx context.Context, problemType string, status int, titleKey string, detailKey string) *problem.Problem {
+	return &problem.Problem{
+		Type:   problemType,
+		Title:  messages.Get(ctx, titleKey),
+		Status: status,
+		Detail: messages.Get(ctx, detailKey),
+	}
+}
+
+// ReadBody читает непустое тело запроса. При ошибке ответ уже отправлен и возвращается false.
+func ReadBody(responseWriter http.ResponseWriter, request *http.Request, handlerName string) ([]byte, bool) {
+	data, err := io.ReadAll(request.Body)
+	if err != nil {
+		WriteError(responseWriter, request, handlerName, http.StatusInternalServerError, err, messages.RequestReadBodyFailed)
+		return nil, false
+	}
+
+	if len(data) == 0 {
+		WriteError(responseWriter, request, handlerName, http.StatusBadRequest, err, messages.RequestEmptyBody)
+		return nil, false
+	}
+	return data, true
+}
+
+// ReadRequest читает JSON из тела запроса в dtoRequest. При ошибке ответ уже отправлен и возвращается false.
+func ReadRequest(responseWriter http.ResponseWriter, request *http.Request, handlerName string, dtoRequest any, dtoName string) bool {
+	data, ok := ReadBody(responseWriter, request, handlerName)
+	if !ok {
+		return false
+	}
+
+	if err := json.Unmarshal(data, dtoRequest); err != nil {
+		WriteError(responseWriter, request, handlerName, http.StatusBadRequest, err, messages.RequestInvalidJSON, dtoName)
+		return false
+	}
+	return true
+}
+
+// ReadRouteParam читает числовой параметр пути. При ошибке ответ уже отправлен и возвращается false.
+func ReadRouteParam(responseWriter http.ResponseWriter, request *http.Request, handlerName string, key string) (int, bool) {
+	value, err := http_helper.GetRouteParam[int](mux.Vars(request), key)
+	if err != nil {
+		switch err {
+		case http_helper.ErrParamIsEmpty:
+			WriteError(responseWriter, request, handlerName, http.StatusBadRequest, err, messages.RequestMissingParam, key)
+		default:
+			WriteError(responseWriter, request, handlerName, http.StatusInternalServerError, err, messages.RequestInvalidParam, key)
+		}
+		return 0, false
+	}
+	return value, true
+}
+
+// WriteResponse отправляет dtoResponse в формате JSON
+func WriteResponse(responseWriter http.ResponseWriter, request *http.Request, handlerName string, status int, dtoResponse any) {
+	responseWriter.WriteHeader(status)
+	if err := json.NewEncoder(responseWriter).Encode(dtoResponse); err != nil {
+		log.Println(
+			handlerName, request.URL.Path,
+			"from", request.Host,
+			"Ошибка конвертации ответа в JSON!",
+			err,
+		)
+		return
+	}
+
+	log.Println(
+		handlerName, request.URL.Path,
+		"from", request.Host,
+		"result:",
+		dtoResponse,
+	)
+}
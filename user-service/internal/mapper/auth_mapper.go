@@ -0,0 +1,26 @@
+package mapper
+
+import (
+	"time"
+	"user-service/internal/dto"
+	"user-service/internal/model"
+)
+
+const tokenType string = "Bearer"
+
+type AuthMapper struct{}
+
+func (authMapper AuthMapper) ToDto(authTokenModel model.AuthTokenModel) *dto.TokenResponse {
+	expiresIn := authTokenModel.ExpiresAt - time.Now().Unix()
+	if expiresIn < 0 {
+		expiresIn = 0
+	}
+	return &dto.TokenResponse{
+		AccessToken:      authTokenModel.AccessToken,
+		TokenType:        tokenType,
+		ExpiresIn:        expiresIn,
+		ExpiresAt:        authTokenModel.ExpiresAt,
+		RefreshToken:     authTokenModel.RefreshToken,
+		RefreshExpiresAt: authTokenModel.RefreshExpiresAt,
+	}
+}
@@ -0,0 +1,42 @@
+package mapper
+
+import (
+	"user-service/internal/dto"
+	"user-service/pkg/abac"
+	"user-service/pkg/jwt/jwt_metadata"
+)
+
+type PolicyMapper struct{}
+
+func (policyMapper PolicyMapper) SubjectToPayload(explainSubject dto.ExplainSubject) *jwt_metadata.Payload {
+	return &jwt_metadata.Payload{
+		BasePayload: jwt_metadata.BasePayload{
+			Subject: explainSubject.Subject,
+		},
+		Roles:       explainSubject.Roles,
+		Permissions: explainSubject.Permissions,
+		Tenant:      explainSubject.Tenant,
+	}
+}
+
+func (policyMapper PolicyMapper) ToDto(decision abac.Decision) *dto.ExplainResponse {
+	rules := make([]dto.RuleTraceResponse, 0, len(decision.Trace))
+	for _, trace := range decision.Trace {
+		reasons := trace.Reasons
+		if reasons == nil {
+			reasons = make([]string, 0)
+		}
+		rules = append(rules, dto.RuleTraceResponse{
+			Rule:    trace.Rule,
+			Effect:  string(trace.Effect),
+			Matched: trace.Matched,
+			Reasons: reasons,
+		})
+	}
+	return &dto.ExplainResponse{
+		Allowed: decision.Allowed,
+		Rule:    decision.Rule,
+		Reason:  decision.Reason,
+		Rules:   rules,
+	}
+}
@@ -0,0 +1,69 @@
+package mapper
+
+import (
+	"slices"
+	"user-service/internal/dto"
+	"user-service/internal/model"
+
+	"github.com/go-playground/validator"
+)
+
+type RBACMapper struct{}
+
+func (rbacMapper RBACMapper) RoleToModel(roleRequest dto.RoleRequest) (*model.RoleModel, error) {
+	if err := validator.New().Struct(roleRequest); err != nil {
+		return nil, err
+	}
+	permissions := slices.Clone(roleRequest.Permissions)
+	slices.Sort(permissions)
+	return &model.RoleModel{
+		Name:        roleRequest.Name,
+		Description: roleRequest.Description,
+		Permissions: slices.Compact(permissions),
+	}, nil
+}
+
+func (rbacMapper RBACMapper) RoleToDto(roleModel model.RoleModel) *dto.RoleResponse {
+	permissions := roleModel.Permissions
+	if permissions == nil {
+		permissions = make([]string, 0)
+	}
+	return &dto.RoleResponse{
+		Id:          roleModel.Id,
+		Name:        roleModel.Name,
+		Description: roleModel.Description,
+		Permissions: permissions,
+	}
+}
+
+func (rbacMapper RBACMapper) PermissionToModel(permissionRequest dto.PermissionRequest) (*model.PermissionModel, error) {
+	if err := validator.New().Struct(permissionRequest); err != nil {
+		return nil, err
+	}
+	return &model.PermissionModel{
+		Name:        permissionRequest.Name,
+		Description: permissionRequest.Description,
+	}, nil
+}
+
+func (rbacMapper RBACMapper) PermissionToDto(permissionModel model.PermissionModel) *dto.PermissionResponse {
+	return &dto.PermissionResponse{
+		Id:          permissionModel.Id,
+		Name:        permissionModel.Name,
+		Description: permissionModel.Description,
+	}
+}
+
+func (rbacMapper RBACMapper) UserRolesToDto(userId int, roles, permissions []string) *dto.UserRolesResponse {
+	if roles == nil {
+		roles = make([]string, 0)
+	}
+	if permissions == nil {
+		permissions = make([]string, 0)
+	}
+	return &dto.UserRolesResponse{
+		UserId:      userId,
+		Roles:       roles,
+		Permissions: permissions,
+	}
+}
@@ -1,30 +1,152 @@
 package mapper
 
 import (
-	"crypto/sha256"
-	"fmt"
+	"errors"
+	"reflect"
+	"strings"
 	"user-service/internal/dto"
 	"user-service/internal/model"
+	"user-service/internal/user"
+	"user-service/pkg/password"
 
 	"github.com/go-playground/validator"
 )
 
+// passwordHasher хеширует пароли перед сохранением в базу данных
+var passwordHasher *password.PasswordHasher = password.New(
+	password.NewArgon2idHasher(password.DefaultArgon2idParams),
+	password.NewSHA256Hasher(),
+)
+
+// SetPasswordHasher задает алгоритм хеширования паролей
+func SetPasswordHasher(hasher *password.PasswordHasher) {
+	passwordHasher = hasher
+}
+
+// requestValidator проверяет запросы и называет поля так же, как в JSON
+var requestValidator *validator.Validate = newRequestValidator()
+
+func newRequestValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	})
+	return validate
+}
+
 type UserMapper struct{}
 
+// ToModel проверяет запрос и хеширует пароль. Если запрос не прошел проверку,
+// возвращается user.ValidationError с описанием каждого некорректного поля.
 func (userMapper UserMapper) ToModel(userRequest dto.UserRequest) (*model.UserModel, error) {
-	if err := validator.New().Struct(userRequest); err != nil {
+	if err := requestValidator.Struct(userRequest); err != nil {
+		return nil, toValidationError(err)
+	}
+	passwordHash, err := passwordHasher.Hash(userRequest.Password)
+	if err != nil {
 		return nil, err
 	}
-	password_hash := sha256.Sum256([]byte(userRequest.Password))
 	return &model.UserModel{
 		Username:      userRequest.Username,
-		Password_Hash: fmt.Sprintf("%x", password_hash),
+		Password_Hash: passwordHash,
 	}, nil
 }
 
+// ToPatchRequest возвращает документ пользователя, к которому применяются изменения PATCH.
+// Пароль хранится в виде хеша, поэтому в документ не попадает.
+func (userMapper UserMapper) ToPatchRequest(userModel model.UserModel) *dto.UserPatchRequest {
+	return &dto.UserPatchRequest{
+		Username: &userModel.Username,
+	}
+}
+
+// PatchToModel проверяет только поля, измененные относительно userModel, и хеширует новый пароль.
+// Неизмененные поля возвращаются пустыми, чтобы UserRepository.Update оставил их без изменений.
+// Удаленное имя пользователя считается незаполненным обязательным полем.
+func (userMapper UserMapper) PatchToModel(userModel model.UserModel, patchRequest dto.UserPatchRequest) (*model.UserModel, error) {
+	var userRequest dto.UserRequest
+	var fields []string
+	if patchRequest.Username == nil || *patchRequest.Username != userModel.Username {
+		if patchRequest.Username != nil {
+			userRequest.Username = *patchRequest.Username
+		}
+		fields = append(fields, "Username")
+	}
+	if patchRequest.Password != nil {
+		userRequest.Password = *patchRequest.Password
+		fields = append(fields, "Password")
+	}
+	if len(fields) == 0 {
+		return &model.UserModel{}, nil
+	}
+	if err := requestValidator.StructPartial(userRequest, fields...); err != nil {
+		return nil, toValidationError(err)
+	}
+
+	patchedModel := &model.UserModel{Username: userRequest.Username}
+	if patchRequest.Password != nil {
+		passwordHash, err := passwordHasher.Hash(userRequest.Password)
+		if err != nil {
+			return nil, err
+		}
+		patchedModel.Password_Hash = passwordHash
+	}
+	return patchedModel, nil
+}
+
+// ToTenant проверяет запрос на назначение организации и возвращает ее или user.ValidationError
+func (userMapper UserMapper) ToTenant(tenantRequest dto.UserTenantRequest) (string, error) {
+	if err := requestValidator.Struct(tenantRequest); err != nil {
+		return "", toValidationError(err)
+	}
+	return tenantRequest.Tenant, nil
+}
+
 func (userMapper UserMapper) ToDto(userModel model.UserModel) *dto.UserResponse {
 	return &dto.UserResponse{
 		Id:       userModel.Id,
 		Username: userModel.Username,
+		Tenant:   userModel.Tenant,
+	}
+}
+
+// ToDtoList преобразует пользователей страницы в список ответов
+func (userMapper UserMapper) ToDtoList(userModels []*model.UserModel) []dto.UserResponse {
+	dtoResponses := make([]dto.UserResponse, 0, len(userModels))
+	for _, userModel := range userModels {
+		dtoResponses = append(dtoResponses, *userMapper.ToDto(*userModel))
+	}
+	return dtoResponses
+}
+
+// ToPageDto оборачивает страницу пользователей в конверт. page — номер страницы с единицы
+// или 0, если страница выбрана курсором.
+func (userMapper UserMapper) ToPageDto(userPage user.UserPage, page int, limit int) *dto.UserPageResponse {
+	return &dto.UserPageResponse{
+		Items:          userMapper.ToDtoList(userPage.Users),
+		Total:          userPage.Total,
+		TotalEstimated: userPage.TotalEstimated,
+		Page:           page,
+		Limit:          limit,
+		HasNext:        userPage.Next != "",
+		Next:           userPage.Next,
+		Prev:           userPage.Prev,
+	}
+}
+
+// toValidationError преобразует ошибки validator в user.ValidationError
+func toValidationError(err error) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+	fields := make([]user.FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fields = append(fields, user.FieldError{
+			Field: fieldError.Field(),
+			Rule:  fieldError.Tag(),
+			Param: fieldError.Param(),
+		})
 	}
+	return &user.ValidationError{Fields: fields}
 }
@@ -0,0 +1,103 @@
+package messages
+
+var en = map[string]string{
+	RequestReadBodyFailed: "Failed to read the request body!",
+	RequestEmptyBody:      "The request body is empty!",
+	RequestInvalidJSON:    "Failed to convert JSON to %s!",
+	RequestMissingParam:   "The request parameter %s is not set!",
+	RequestInvalidParam:   "Failed to convert the request parameter %s!",
+	RequestInvalid:        "Validation of %s failed!",
+
+	PatchUnsupportedType: "Unsupported patch document type %s! Supported types: %s",
+	PatchInvalid:         "The patch document is not valid JSON!",
+	PatchNotApplicable:   "The patch document cannot be applied!",
+	PatchTestFailed:      "A test operation of the patch document failed!",
+
+	AuthUnauthorized:        "Authentication required",
+	AuthAccessDenied:        "Access denied",
+	AuthInvalidCredentials:  "Invalid username or password!",
+	AuthInvalidRefreshToken: "Invalid refresh token!",
+	AuthRefreshTokenReused:  "The refresh token has already been used, the session is revoked!",
+	AuthInvalidToken:        "Invalid token!",
+	AuthPayloadFailed:       "Failed to get the token payload!",
+	AuthLoginFailed:         "Failed to issue the token!",
+	AuthRefreshFailed:       "Failed to refresh the token!",
+	AuthRevokeFailed:        "Failed to revoke the token!",
+	AuthEncodeFailed:        "Failed to convert %s to JSON!",
+
+	PolicyResourceNotFound: "No user with the given id exists!",
+	PolicyDecideFailed:     "Failed to evaluate the decision!",
+
+	UserConvertFailed:   "Failed to convert dto.UserRequest to model.UserModel!",
+	UserCreateFailed:    "Failed to create the database record!",
+	UserGetAllFailed:    "Failed to get the list of users!",
+	UserGetOneFailed:    "Failed to get the user!",
+	UserUpdateFailed:    "Failed to update the database record!",
+	UserPatchFailed:     "Failed to patch the user!",
+	UserDeleteFailed:    "Failed to delete the user!",
+	UserSetTenantFailed: "Failed to set the user tenant!",
+	UserNotFound:        "User not found",
+	UserUsernameTaken:   "A user with this username already exists",
+	UserForbidden:       "The operation on the user is forbidden",
+	UserValidation:      "Invalid user data",
+	UserVersionMismatch: "The user has been modified by another request",
+
+	RBACRoleConvertFailed:       "Failed to convert dto.RoleRequest to model.RoleModel!",
+	RBACPermissionConvertFailed: "Failed to convert dto.PermissionRequest to model.PermissionModel!",
+	RBACCreateRoleFailed:        "Failed to create the role!",
+	RBACGetRolesFailed:          "Failed to get the list of roles!",
+	RBACGetRoleFailed:           "Failed to get the role!",
+	RBACUpdateRoleFailed:        "Failed to update the role!",
+	RBACDeleteRoleFailed:        "Failed to delete the role!",
+	RBACCreatePermissionFailed:  "Failed to create the permission!",
+	RBACGetPermissionsFailed:    "Failed to get the list of permissions!",
+	RBACGetPermissionFailed:     "Failed to get the permission!",
+	RBACUpdatePermissionFailed:  "Failed to update the permission!",
+	RBACDeletePermissionFailed:  "Failed to delete the permission!",
+	RBACGetUserRolesFailed:      "Failed to get the user roles!",
+	RBACAssignRoleFailed:        "Failed to assign the role to the user!",
+	RBACUnassignRoleFailed:      "Failed to unassign the role from the user!",
+	RBACRoleNotFound:            "Role not found",
+	RBACRoleAlreadyExists:       "A role with this name already exists",
+	RBACPermissionNotFound:      "Permission not found",
+	RBACPermissionAlreadyExists: "A permission with this name already exists",
+	RBACRoleNotAssigned:         "The role is not assigned to the user",
+
+	JWTTokenGeneration:             "Failed to generate the token",
+	JWTConvertToJson:               "Failed to convert to JSON",
+	JWTInvalidTokenFormat:          "Invalid token format",
+	JWTExtractTokenIsEmpty:         "No token in the Authorization header",
+	JWTSignatureVerificationFailed: "Signature verification failed",
+	JWTMissingJWTID:                "The token identifier (jti) is missing",
+	JWTTokenRevoked:                "The token has been revoked",
+	JWTInvalidHeader:               "Invalid token header",
+	JWTInvalidHeaderFormat:         "Invalid token header format",
+	JWTUnsupportedTypeToken:        "Unsupported token type",
+	JWTMissingAlgorithm:            "The signing algorithm is missing",
+	JWTUnsupportedAlgorithm:        "Unsupported signing algorithm",
+	JWTAlgorithmMismatch:           "The signing algorithm does not match the one configured for the key",
+	JWTInvalidKeyType:              "The key type does not match the signing algorithm",
+	JWTUnknownKeyID:                "Unknown key identifier (kid)",
+	JWTInvalidPayload:              "Invalid token payload",
+	JWTInvalidPayloadFormat:        "Invalid token payload format",
+	JWTInvalidTimeRange:            "The token expires before it becomes valid",
+	JWTTokenExpired:                "The token has expired",
+	JWTNotBefore:                   "The token is not valid yet (nbf)",
+	JWTInvalidIssuer:               "The token was issued by an unknown issuer (iss)",
+	JWTInvalidAudience:             "The token is not intended for this audience (aud)",
+	JWTInvalidAudienceFormat:       "Invalid audience format (aud)",
+
+	ValidationRequired:     "%[1]s is required",
+	ValidationMin:          "%[1]s must be at least %[2]s characters long",
+	ValidationMax:          "%[1]s must be at most %[2]s characters long",
+	ValidationGte:          "%[1]s must be greater than or equal to %[2]s",
+	ValidationLte:          "%[1]s must be less than or equal to %[2]s",
+	ValidationNumeric:      "%[1]s must be a number",
+	ValidationBoolean:      "%[1]s must be true or false",
+	ValidationDatetime:     "%[1]s must be a date and time in %[2]s format",
+	ValidationOneOf:        "%[1]s must be one of: %[2]s",
+	ValidationUnique:       "%[1]s must not contain duplicate values",
+	ValidationExcludedWith: "%[1]s cannot be combined with %[2]s",
+	ValidationCursor:       "%[1]s must be a cursor returned with a page",
+	ValidationInvalid:      "%[1]s failed the %[2]s check",
+}
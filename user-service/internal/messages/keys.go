@@ -0,0 +1,109 @@
+package messages
+
+// Ключи сообщений. Ключи стабильны: клиенты и переводы опираются на них,
+// поэтому при изменении текста сообщения ключ не меняется.
+const (
+	RequestReadBodyFailed string = "request.read_body_failed"
+	RequestEmptyBody      string = "request.empty_body"
+	RequestInvalidJSON    string = "request.invalid_json"
+	RequestMissingParam   string = "request.missing_param"
+	RequestInvalidParam   string = "request.invalid_param"
+	RequestInvalid        string = "request.invalid"
+
+	PatchUnsupportedType string = "patch.unsupported_type"
+	PatchInvalid         string = "patch.invalid"
+	PatchNotApplicable   string = "patch.not_applicable"
+	PatchTestFailed      string = "patch.test_failed"
+
+	AuthUnauthorized        string = "auth.unauthorized"
+	AuthAccessDenied        string = "auth.access_denied"
+	AuthInvalidCredentials  string = "auth.invalid_credentials"
+	AuthInvalidRefreshToken string = "auth.invalid_refresh_token"
+	AuthRefreshTokenReused  string = "auth.refresh_token_reused"
+	AuthInvalidToken        string = "auth.invalid_token"
+	AuthPayloadFailed       string = "auth.payload_failed"
+	AuthLoginFailed         string = "auth.login_failed"
+	AuthRefreshFailed       string = "auth.refresh_failed"
+	AuthRevokeFailed        string = "auth.revoke_failed"
+	AuthEncodeFailed        string = "auth.encode_failed"
+
+	PolicyResourceNotFound string = "policy.resource_not_found"
+	PolicyDecideFailed     string = "policy.decide_failed"
+
+	UserConvertFailed   string = "user.convert_failed"
+	UserCreateFailed    string = "user.create_failed"
+	UserGetAllFailed    string = "user.get_all_failed"
+	UserGetOneFailed    string = "user.get_one_failed"
+	UserUpdateFailed    string = "user.update_failed"
+	UserPatchFailed     string = "user.patch_failed"
+	UserDeleteFailed    string = "user.delete_failed"
+	UserSetTenantFailed string = "user.set_tenant_failed"
+	UserNotFound        string = "user.not_found"
+	UserUsernameTaken   string = "user.username_taken"
+	UserForbidden       string = "user.forbidden"
+	UserValidation      string = "user.validation"
+	UserVersionMismatch string = "user.version_mismatch"
+
+	RBACRoleConvertFailed       string = "rbac.role_convert_failed"
+	RBACPermissionConvertFailed string = "rbac.permission_convert_failed"
+	RBACCreateRoleFailed        string = "rbac.create_role_failed"
+	RBACGetRolesFailed          string = "rbac.get_roles_failed"
+	RBACGetRoleFailed           string = "rbac.get_role_failed"
+	RBACUpdateRoleFailed        string = "rbac.update_role_failed"
+	RBACDeleteRoleFailed        string = "rbac.delete_role_failed"
+	RBACCreatePermissionFailed  string = "rbac.create_permission_failed"
+	RBACGetPermissionsFailed    string = "rbac.get_permissions_failed"
+	RBACGetPermissionFailed     string = "rbac.get_permission_failed"
+	RBACUpdatePermissionFailed  string = "rbac.update_permission_failed"
+	RBACDeletePermissionFailed  string = "rbac.delete_permission_failed"
+	RBACGetUserRolesFailed      string = "rbac.get_user_roles_failed"
+	RBACAssignRoleFailed        string = "rbac.assign_role_failed"
+	RBACUnassignRoleFailed      string = "rbac.unassign_role_failed"
+	RBACRoleNotFound            string = "rbac.role_not_found"
+	RBACRoleAlreadyExists       string = "rbac.role_already_exists"
+	RBACPermissionNotFound      string = "rbac.permission_not_found"
+	RBACPermissionAlreadyExists string = "rbac.permission_already_exists"
+	RBACRoleNotAssigned         string = "rbac.role_not_assigned"
+
+	JWTTokenGeneration             string = "jwt.token_generation"
+	JWTConvertToJson               string = "jwt.convert_to_json"
+	JWTInvalidTokenFormat          string = "jwt.invalid_token_format"
+	JWTExtractTokenIsEmpty         string = "jwt.extract_token_is_empty"
+	JWTSignatureVerificationFailed string = "jwt.signature_verification_failed"
+	JWTMissingJWTID                string = "jwt.missing_jti"
+	JWTTokenRevoked                string = "jwt.token_revoked"
+	JWTInvalidHeader               string = "jwt.invalid_header"
+	JWTInvalidHeaderFormat         string = "jwt.invalid_header_format"
+	JWTUnsupportedTypeToken        string = "jwt.unsupported_token_type"
+	JWTMissingAlgorithm            string = "jwt.missing_algorithm"
+	JWTUnsupportedAlgorithm        string = "jwt.unsupported_algorithm"
+	JWTAlgorithmMismatch           string = "jwt.algorithm_mismatch"
+	JWTInvalidKeyType              string = "jwt.invalid_key_type"
+	JWTUnknownKeyID                string = "jwt.unknown_kid"
+	JWTInvalidPayload              string = "jwt.invalid_payload"
+	JWTInvalidPayloadFormat        string = "jwt.invalid_payload_format"
+	JWTInvalidTimeRange            string = "jwt.invalid_time_range"
+	JWTTokenExpired                string = "jwt.token_expired"
+	JWTNotBefore                   string = "jwt.not_before"
+	JWTInvalidIssuer               string = "jwt.invalid_issuer"
+	JWTInvalidAudience             string = "jwt.invalid_audience"
+	JWTInvalidAudienceFormat       string = "jwt.invalid_audience_format"
+
+	// Сообщения проверки полей получают аргументы: имя поля и параметр правила
+	ValidationPrefix       string = "validation."
+	ValidationRequired     string = "validation.required"
+	ValidationMin          string = "validation.min"
+	ValidationMax          string = "validation.max"
+	ValidationGte          string = "validation.gte"
+	ValidationLte          string = "validation.lte"
+	ValidationNumeric      string = "validation.numeric"
+	ValidationBoolean      string = "validation.boolean"
+	ValidationDatetime     string = "validation.datetime"
+	ValidationOneOf        string = "validation.oneof"
+	ValidationUnique       string = "validation.unique"
+	ValidationExcludedWith string = "validation.excluded_with"
+	ValidationCursor       string = "validation.cursor"
+	// ValidationInvalid используется для правил без собственного сообщения
+	// и получает аргументы: имя поля и название правила
+	ValidationInvalid string = "validation.invalid"
+)
@@ -0,0 +1,97 @@
+package messages
+
+import (
+	"context"
+	"errors"
+	"user-service/pkg/i18n"
+	"user-service/pkg/jwt/jwt_errors"
+)
+
+// DefaultLanguage — язык сообщений, пока в конфигурации не задан другой
+const DefaultLanguage string = "ru"
+
+var translations = map[string]map[string]string{
+	"ru": ru,
+	"en": en,
+}
+
+var catalog *i18n.Catalog = mustNewCatalog(DefaultLanguage)
+
+// errorKeys сопоставляет ошибки проверки токена с ключами сообщений.
+// Ошибки предметных областей сопоставляются с ключами в их контроллерах.
+var errorKeys = []struct {
+	err error
+	key string
+}{
+	{jwt_errors.ErrTokenGeneration, JWTTokenGeneration},
+	{jwt_errors.ErrConvertToJson, JWTConvertToJson},
+	{jwt_errors.ErrInvalidTokenFormat, JWTInvalidTokenFormat},
+	{jwt_errors.ErrExtractTokenIsEmpty, JWTExtractTokenIsEmpty},
+	{jwt_errors.ErrSignatureVerificationFailed, JWTSignatureVerificationFailed},
+	{jwt_errors.ErrMissingJWTID, JWTMissingJWTID},
+	{jwt_errors.ErrTokenRevoked, JWTTokenRevoked},
+	{jwt_errors.ErrInvalidHeader, JWTInvalidHeader},
+	{jwt_errors.ErrInvalidHeaderFormat, JWTInvalidHeaderFormat},
+	{jwt_errors.ErrUnsupportedTypeToken, JWTUnsupportedTypeToken},
+	{jwt_errors.ErrMissingAlgorithm, JWTMissingAlgorithm},
+	{jwt_errors.ErrUnsupportedAlgorithm, JWTUnsupportedAlgorithm},
+	{jwt_errors.ErrAlgorithmMismatch, JWTAlgorithmMismatch},
+	{jwt_errors.ErrInvalidKeyType, JWTInvalidKeyType},
+	{jwt_errors.ErrUnknownKeyID, JWTUnknownKeyID},
+	{jwt_errors.ErrInvalidPayload, JWTInvalidPayload},
+	{jwt_errors.ErrInvalidPayloadFormat, JWTInvalidPayloadFormat},
+	{jwt_errors.ErrInvalidTimeRange, JWTInvalidTimeRange},
+	{jwt_errors.ErrTokenExpired, JWTTokenExpired},
+	{jwt_errors.ErrNotBeforeError, JWTNotBefore},
+	{jwt_errors.ErrInvalidIssuer, JWTInvalidIssuer},
+	{jwt_errors.ErrInvalidAudience, JWTInvalidAudience},
+	{jwt_errors.ErrInvalidAudienceFormat, JWTInvalidAudienceFormat},
+}
+
+func mustNewCatalog(defaultLanguage string) *i18n.Catalog {
+	newCatalog, err := i18n.New(defaultLanguage, translations)
+	if err != nil {
+		panic(err)
+	}
+	return newCatalog
+}
+
+// SetDefaultLanguage задает язык сообщений для клиентов, не передавших поддерживаемый язык
+func SetDefaultLanguage(lang string) error {
+	newCatalog, err := i18n.New(lang, translations)
+	if err != nil {
+		return err
+	}
+	catalog = newCatalog
+	return nil
+}
+
+// Negotiate выбирает язык ответа по заголовку Accept-Language
+func Negotiate(acceptLanguage string) string {
+	return catalog.Negotiate(acceptLanguage)
+}
+
+// Get возвращает сообщение key на языке запроса из контекста ctx
+func Get(ctx context.Context, key string, args ...any) string {
+	return catalog.Translate(i18n.LanguageFromContext(ctx), key, args...)
+}
+
+// Error возвращает сообщение для известной ошибки err или сообщение fallbackKey,
+// если ошибка неизвестна. Текст неизвестных ошибок клиенту не передается.
+func Error(ctx context.Context, err error, fallbackKey string) string {
+	for _, errorKey := range errorKeys {
+		if errors.Is(err, errorKey.err) {
+			return Get(ctx, errorKey.key)
+		}
+	}
+	return Get(ctx, fallbackKey)
+}
+
+// Field возвращает сообщение о нарушении правила проверки rule с параметром param в поле field
+func Field(ctx context.Context, field string, rule string, param string) string {
+	lang := i18n.LanguageFromContext(ctx)
+	if message, ok := catalog.Lookup(lang, ValidationPrefix+rule, field, param); ok {
+		return message
+	}
+	return catalog.Translate(lang, ValidationInvalid, field, rule)
+}
@@ -0,0 +1,103 @@
+package messages
+
+var ru = map[string]string{
+	RequestReadBodyFailed: "Ошибка чтения тела запроса!",
+	RequestEmptyBody:      "Получено пустое тело запроса!",
+	RequestInvalidJSON:    "Ошибка конвертации JSON в %s!",
+	RequestMissingParam:   "Ошибка заполнения параметра запроса %s!",
+	RequestInvalidParam:   "Ошибка конвертации параметра запроса %s!",
+	RequestInvalid:        "Ошибка валидации %s!",
+
+	PatchUnsupportedType: "Неподдерживаемый тип документа изменений %s! Поддерживаются: %s",
+	PatchInvalid:         "Документ изменений не является корректным JSON!",
+	PatchNotApplicable:   "Документ изменений не может быть применен!",
+	PatchTestFailed:      "Условие test документа изменений не выполнено!",
+
+	AuthUnauthorized:        "Требуется аутентификация",
+	AuthAccessDenied:        "Доступ запрещен",
+	AuthInvalidCredentials:  "Неверное имя пользователя или пароль!",
+	AuthInvalidRefreshToken: "Недействительный refresh-токен!",
+	AuthRefreshTokenReused:  "Refresh-токен уже был использован, сессия отозвана!",
+	AuthInvalidToken:        "Недействительный токен!",
+	AuthPayloadFailed:       "Ошибка получения полезной нагрузки токена!",
+	AuthLoginFailed:         "Ошибка выдачи токена!",
+	AuthRefreshFailed:       "Ошибка обновления токена!",
+	AuthRevokeFailed:        "Ошибка отзыва токена!",
+	AuthEncodeFailed:        "Ошибка конвертации %s в JSON!",
+
+	PolicyResourceNotFound: "Пользователь с заданным id не существует!",
+	PolicyDecideFailed:     "Ошибка вычисления решения!",
+
+	UserConvertFailed:   "Ошибка конвертации dto.UserRequest в model.UserModel!",
+	UserCreateFailed:    "Ошибка создания записи в базе данных!",
+	UserGetAllFailed:    "Ошибка получения списка пользователей!",
+	UserGetOneFailed:    "Ошибка получения пользователя!",
+	UserUpdateFailed:    "Ошибка обновления записи в базе данных!",
+	UserPatchFailed:     "Ошибка изменения пользователя!",
+	UserDeleteFailed:    "Ошибка удаления пользователя!",
+	UserSetTenantFailed: "Ошибка назначения организации пользователю!",
+	UserNotFound:        "Пользователь не найден",
+	UserUsernameTaken:   "Пользователь с заданным именем уже существует",
+	UserForbidden:       "Операция над пользователем запрещена",
+	UserValidation:      "Некорректные данные пользователя",
+	UserVersionMismatch: "Пользователь изменен другим запросом",
+
+	RBACRoleConvertFailed:       "Ошибка конвертации dto.RoleRequest в model.RoleModel!",
+	RBACPermissionConvertFailed: "Ошибка конвертации dto.PermissionRequest в model.PermissionModel!",
+	RBACCreateRoleFailed:        "Ошибка создания роли!",
+	RBACGetRolesFailed:          "Ошибка получения списка ролей!",
+	RBACGetRoleFailed:           "Ошибка получения роли!",
+	RBACUpdateRoleFailed:        "Ошибка обновления роли!",
+	RBACDeleteRoleFailed:        "Ошибка удаления роли!",
+	RBACCreatePermissionFailed:  "Ошибка создания права!",
+	RBACGetPermissionsFailed:    "Ошибка получения списка прав!",
+	RBACGetPermissionFailed:     "Ошибка получения права!",
+	RBACUpdatePermissionFailed:  "Ошибка обновления права!",
+	RBACDeletePermissionFailed:  "Ошибка удаления права!",
+	RBACGetUserRolesFailed:      "Ошибка получения ролей пользователя!",
+	RBACAssignRoleFailed:        "Ошибка назначения роли пользователю!",
+	RBACUnassignRoleFailed:      "Ошибка снятия роли с пользователя!",
+	RBACRoleNotFound:            "Роль не найдена",
+	RBACRoleAlreadyExists:       "Роль с заданным именем уже существует",
+	RBACPermissionNotFound:      "Право не найдено",
+	RBACPermissionAlreadyExists: "Право с заданным именем уже существует",
+	RBACRoleNotAssigned:         "Роль не назначена пользователю",
+
+	JWTTokenGeneration:             "Ошибка генерации токена",
+	JWTConvertToJson:               "Ошибка конвертации в JSON",
+	JWTInvalidTokenFormat:          "Неверный формат токена",
+	JWTExtractTokenIsEmpty:         "Токен не передан в заголовке Authorization",
+	JWTSignatureVerificationFailed: "Проверка подписи не удалась",
+	JWTMissingJWTID:                "Идентификатор токена (jti) не указан",
+	JWTTokenRevoked:                "Токен отозван",
+	JWTInvalidHeader:               "Неверный заголовок токена",
+	JWTInvalidHeaderFormat:         "Неверный формат заголовка токена",
+	JWTUnsupportedTypeToken:        "Неподдерживаемый тип токена",
+	JWTMissingAlgorithm:            "Алгоритм подписи не указан",
+	JWTUnsupportedAlgorithm:        "Неподдерживаемый алгоритм подписи",
+	JWTAlgorithmMismatch:           "Алгоритм подписи не совпадает с настроенным для ключа",
+	JWTInvalidKeyType:              "Тип ключа не соответствует алгоритму подписи",
+	JWTUnknownKeyID:                "Неизвестный идентификатор ключа (kid)",
+	JWTInvalidPayload:              "Неверная полезная нагрузка токена",
+	JWTInvalidPayloadFormat:        "Неверный формат полезной нагрузки токена",
+	JWTInvalidTimeRange:            "Время истечения токена меньше времени начала действия",
+	JWTTokenExpired:                "Токен истек",
+	JWTNotBefore:                   "Токен еще не активен (nbf)",
+	JWTInvalidIssuer:               "Токен выпущен посторонним издателем (iss)",
+	JWTInvalidAudience:             "Токен не предназначен для данного получателя (aud)",
+	JWTInvalidAudienceFormat:       "Неверный формат получателей (aud)",
+
+	ValidationRequired:     "Поле %[1]s обязательно",
+	ValidationMin:          "Длина поля %[1]s должна быть не меньше %[2]s",
+	ValidationMax:          "Длина поля %[1]s должна быть не больше %[2]s",
+	ValidationGte:          "Значение поля %[1]s должно быть не меньше %[2]s",
+	ValidationLte:          "Значение поля %[1]s должно быть не больше %[2]s",
+	ValidationNumeric:      "Значение поля %[1]s должно быть числом",
+	ValidationBoolean:      "Значение поля %[1]s должно быть true или false",
+	ValidationDatetime:     "Значение поля %[1]s должно быть датой и временем в формате %[2]s",
+	ValidationOneOf:        "Значение поля %[1]s должно быть одним из: %[2]s",
+	ValidationUnique:       "Значения в поле %[1]s не должны повторяться",
+	ValidationExcludedWith: "Поле %[1]s нельзя задавать вместе с %[2]s",
+	ValidationCursor:       "Значение поля %[1]s должно быть курсором, полученным вместе со страницей",
+	ValidationInvalid:      "Поле %[1]s не прошло проверку %[2]s",
+}
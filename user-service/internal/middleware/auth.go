@@ -2,23 +2,35 @@ package middleware
 
 import (
 	"net/http"
-	"slices"
-	"strings"
+	"user-service/internal/http_response"
+	"user-service/internal/messages"
 	"user-service/pkg/jwt"
+	"user-service/pkg/problem"
+
+	"github.com/gorilla/mux"
 )
 
 var jwtEncoder *jwt.JWTCoder = jwt.NewJWTCoder(Alg, Secret, Issuer, Audience, ExpirationTimeDuration)
 
-func IsAdminMiddleware(nextHandler http.Handler) http.Handler {
+// SetJWTCoder задает кодировщик, которым проверяются токены из запросов
+func SetJWTCoder(jwtCoder *jwt.JWTCoder) {
+	jwtEncoder = jwtCoder
+}
+
+// Authorize проверяет токен запроса и политику доступа policy.
+// Без действительного токена возвращается 401, если политика запрещает запрос — 403.
+// Полезная нагрузка токена передается в nextHandler через контекст запроса.
+func Authorize(policy Policy, nextHandler http.Handler) http.Handler {
 	return http.HandlerFunc(
 		func(responseWriter http.ResponseWriter, request *http.Request) {
 			token, err := GetToken(request)
 			if err != nil {
-				http.Error(responseWriter, err.Error(), http.StatusUnauthorized)
+				detail := messages.Error(request.Context(), err, messages.AuthUnauthorized)
+				http_response.WriteProblem(responseWriter, request, "Middleware.Authorize:", problem.New(http.StatusUnauthorized, detail), err)
 				return
 			}
-			if strings.Compare(token.Payload.Role, "admin") != 0 {
-				http.Error(responseWriter, "Доступ запрещен", http.StatusForbidden)
+			if !policy(request, &token.Payload) {
+				http_response.WriteError(responseWriter, request, "Middleware.Authorize:", http.StatusForbidden, nil, messages.AuthAccessDenied)
 				return
 			}
 			request = PayloadToContext(request, &token.Payload)
@@ -27,20 +39,31 @@ func IsAdminMiddleware(nextHandler http.Handler) http.Handler {
 	)
 }
 
-func IsPermitionDeleteMiddleware(nextHandler http.Handler) http.Handler {
+func IsAuthenticatedMiddleware(nextHandler http.Handler) http.Handler {
+	return Authorize(Authenticated(), nextHandler)
+}
+
+func IsAdminMiddleware(nextHandler http.Handler) http.Handler {
+	return Authorize(RequireRole("admin"), nextHandler)
+}
+
+// SubjectToRouteParam подставляет субъект токена (sub) в параметр пути routeParam,
+// чтобы маршруты вида /users/me обслуживались теми же обработчиками, что и /users/{id}.
+// Должен вызываться после Authorize, которое помещает полезную нагрузку в контекст.
+func SubjectToRouteParam(routeParam string, nextHandler http.Handler) http.Handler {
 	return http.HandlerFunc(
 		func(responseWriter http.ResponseWriter, request *http.Request) {
-			token, err := GetToken(request)
+			payload, err := GetPayload(request)
 			if err != nil {
-				http.Error(responseWriter, err.Error(), http.StatusUnauthorized)
+				http_response.WriteError(responseWriter, request, "Middleware.SubjectToRouteParam:", http.StatusUnauthorized, err, messages.AuthUnauthorized)
 				return
 			}
-			if !slices.Contains(token.Payload.Permissions, "delete") {
-				http.Error(responseWriter, "Доступ запрещен", http.StatusForbidden)
-				return
+			vars := mux.Vars(request)
+			if vars == nil {
+				vars = make(map[string]string)
 			}
-			request = PayloadToContext(request, &token.Payload)
-			nextHandler.ServeHTTP(responseWriter, request)
+			vars[routeParam] = payload.Subject
+			nextHandler.ServeHTTP(responseWriter, mux.SetURLVars(request, vars))
 		},
 	)
 }
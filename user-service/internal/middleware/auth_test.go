@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"user-service/pkg/problem"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestAuthorizeProblem(t *testing.T) {
+	nextHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		t.Fatal("nextHandler не должен вызываться")
+	})
+
+	t.Run("Missing token", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		Authorize(Authenticated(), nextHandler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users", nil))
+		assert.Equal(t, recorder.Code, http.StatusUnauthorized)
+		assert.Equal(t, recorder.Header().Get("Content-Type"), problem.ContentType)
+	})
+
+	t.Run("Missing payload", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		SubjectToRouteParam("id", nextHandler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/me", nil))
+		assert.Equal(t, recorder.Code, http.StatusUnauthorized)
+		assert.Equal(t, recorder.Header().Get("Content-Type"), problem.ContentType)
+	})
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+	"user-service/internal/messages"
+	"user-service/pkg/i18n"
+)
+
+// Localize выбирает язык ответа по заголовку Accept-Language и передает его
+// в nextHandler через контекст запроса. Выбранный язык возвращается в заголовке Content-Language.
+func Localize(nextHandler http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			lang := messages.Negotiate(request.Header.Get("Accept-Language"))
+			responseWriter.Header().Set("Content-Language", lang)
+			responseWriter.Header().Add("Vary", "Accept-Language")
+			request = request.WithContext(i18n.WithLanguage(request.Context(), lang))
+			nextHandler.ServeHTTP(responseWriter, request)
+		},
+	)
+}
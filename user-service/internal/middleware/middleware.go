@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"user-service/pkg/jwt/jwt_errors"
 	"user-service/pkg/jwt/jwt_helper"
 	"user-service/pkg/jwt/jwt_metadata"
 )
@@ -13,6 +14,18 @@ type ContextKey string
 
 const PayloadContextKey ContextKey = "payload"
 
+// TokenDenylist проверяет, отозван ли токен с заданным идентификатором (jti)
+type TokenDenylist interface {
+	ExistByJWTID(ctx context.Context, jwtId string) (bool, error)
+}
+
+var tokenDenylist TokenDenylist
+
+// SetTokenDenylist задает список отозванных токенов, с которым сверяется GetToken
+func SetTokenDenylist(denylist TokenDenylist) {
+	tokenDenylist = denylist
+}
+
 func GetToken(request *http.Request) (*jwt_metadata.Token, error) {
 	tokenString, err := jwt_helper.ExtractToken(request)
 	if err != nil {
@@ -22,11 +35,23 @@ func GetToken(request *http.Request) (*jwt_metadata.Token, error) {
 	if err != nil {
 		return nil, err
 	}
+	if tokenDenylist != nil {
+		if token.Payload.JWTID == "" {
+			return nil, jwt_errors.ErrMissingJWTID
+		}
+		isRevoked, err := tokenDenylist.ExistByJWTID(request.Context(), token.Payload.JWTID)
+		if err != nil {
+			return nil, err
+		}
+		if isRevoked {
+			return nil, jwt_errors.ErrTokenRevoked
+		}
+	}
 	return token, nil
 }
 
 func PayloadToContext(request *http.Request, payload *jwt_metadata.Payload) *http.Request {
-	ctx := context.WithValue(request.Context(), PayloadContextKey, &payload)
+	ctx := context.WithValue(request.Context(), PayloadContextKey, payload)
 	return request.WithContext(ctx)
 }
 
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"user-service/pkg/jwt/jwt_metadata"
+
+	"github.com/gorilla/mux"
+)
+
+// Policy решает, разрешен ли запрос request владельцу токена с полезной нагрузкой payload
+type Policy func(request *http.Request, payload *jwt_metadata.Payload) bool
+
+// Authenticated разрешает запрос любому владельцу действительного токена
+func Authenticated() Policy {
+	return func(request *http.Request, payload *jwt_metadata.Payload) bool {
+		return true
+	}
+}
+
+// RequirePermission требует наличия в токене всех перечисленных прав
+func RequirePermission(permissions ...string) Policy {
+	return func(request *http.Request, payload *jwt_metadata.Payload) bool {
+		for _, permission := range permissions {
+			if !slices.Contains(payload.Permissions, permission) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// RequireRole требует наличия в токене всех перечисленных ролей
+func RequireRole(roles ...string) Policy {
+	return func(request *http.Request, payload *jwt_metadata.Payload) bool {
+		for _, role := range roles {
+			if !slices.Contains(payload.Roles, role) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// RequireOwner разрешает запрос, если субъект токена (sub) совпадает
+// с идентификатором из параметра пути routeParam, то есть пользователь обращается к своей записи
+func RequireOwner(routeParam string) Policy {
+	return func(request *http.Request, payload *jwt_metadata.Payload) bool {
+		id, err := strconv.Atoi(mux.Vars(request)[routeParam])
+		if err != nil {
+			return false
+		}
+		subjectId, err := strconv.Atoi(payload.Subject)
+		if err != nil {
+			return false
+		}
+		return id == subjectId
+	}
+}
+
+// RequireAny разрешает запрос, если его разрешает хотя бы одна из политик
+func RequireAny(policies ...Policy) Policy {
+	return func(request *http.Request, payload *jwt_metadata.Payload) bool {
+		for _, policy := range policies {
+			if policy(request, payload) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// RequireAll разрешает запрос, если его разрешают все политики
+func RequireAll(policies ...Policy) Policy {
+	return func(request *http.Request, payload *jwt_metadata.Payload) bool {
+		for _, policy := range policies {
+			if !policy(request, payload) {
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"user-service/pkg/jwt/jwt_metadata"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/gorilla/mux"
+)
+
+func TestPolicies(t *testing.T) {
+	request := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/users/7", nil), map[string]string{"id": "7"})
+	otherRequest := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/users/8", nil), map[string]string{"id": "8"})
+	payload := &jwt_metadata.Payload{
+		BasePayload: jwt_metadata.BasePayload{Subject: "7"},
+		Roles:       []string{"user"},
+		Permissions: []string{"users:read", "users:write"},
+	}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		expected bool
+	}{
+		{name: "Authenticated", policy: Authenticated(), expected: true},
+		{name: "Permission", policy: RequirePermission("users:read"), expected: true},
+		{name: "All permissions", policy: RequirePermission("users:read", "users:write"), expected: true},
+		{name: "Missing permission", policy: RequirePermission("users:read", "users:delete"), expected: false},
+		{name: "Role", policy: RequireRole("user"), expected: true},
+		{name: "Missing role", policy: RequireRole("admin"), expected: false},
+		{name: "Any", policy: RequireAny(RequireRole("admin"), RequirePermission("users:write")), expected: true},
+		{name: "Any denied", policy: RequireAny(RequireRole("admin"), RequirePermission("users:delete")), expected: false},
+		{name: "All", policy: RequireAll(RequireRole("user"), RequirePermission("users:write")), expected: true},
+		{name: "All denied", policy: RequireAll(RequireRole("user"), RequireRole("admin")), expected: false},
+		{name: "Empty any", policy: RequireAny(), expected: false},
+		{name: "Owner", policy: RequireOwner("id"), expected: true},
+		{name: "Missing route param", policy: RequireOwner("userId"), expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.policy(request, payload), test.expected)
+		})
+	}
+
+	t.Run("Not owner", func(t *testing.T) {
+		assert.Equal(t, RequireOwner("id")(otherRequest, payload), false)
+		ownerOrWriter := RequireAny(RequireOwner("id"), RequirePermission("users:write"))
+		assert.Equal(t, ownerOrWriter(otherRequest, payload), true)
+	})
+}
@@ -0,0 +1,2 @@
+DELETE FROM roles WHERE name IN ('admin', 'user', 'support');
+DELETE FROM permissions WHERE name IN ('users:read', 'users:write', 'users:delete');
@@ -0,0 +1,12 @@
+-- Данные, уже добавленные прежним script/sql/create_users.sql, не дублируются
+INSERT INTO permissions (name, description) VALUES ('users:read', 'Просмотр пользователей') ON CONFLICT (name) DO NOTHING;
+INSERT INTO permissions (name, description) VALUES ('users:write', 'Изменение пользователей') ON CONFLICT (name) DO NOTHING;
+INSERT INTO permissions (name, description) VALUES ('users:delete', 'Удаление пользователей') ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO roles (name, description) VALUES ('admin', 'Администратор') ON CONFLICT (name) DO NOTHING;
+INSERT INTO roles (name, description) VALUES ('user', 'Пользователь: доступ только к своей записи') ON CONFLICT (name) DO NOTHING;
+INSERT INTO roles (name, description) VALUES ('support', 'Поддержка: права определяются правилами config/policies.yml') ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id FROM roles, permissions WHERE roles.name = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,7 @@
+DROP INDEX idx_users_created_at;
+DROP INDEX idx_users_username_trgm;
+DROP INDEX idx_users_username_prefix;
+
+ALTER TABLE users DROP COLUMN created_at;
+
+-- Расширение pg_trgm не удаляется: им могут пользоваться другие объекты базы
@@ -0,0 +1,10 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE users ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+-- Поиск по началу имени (LIKE 'prefix%') при любых правилах сортировки базы
+CREATE INDEX idx_users_username_prefix ON users (username text_pattern_ops);
+-- Поиск подстроки имени (ILIKE '%q%')
+CREATE INDEX idx_users_username_trgm ON users USING GIN (username gin_trgm_ops);
+-- Отбор и сортировка по времени создания; id делает порядок однозначным
+CREATE INDEX idx_users_created_at ON users (created_at, id);
@@ -0,0 +1,9 @@
+package migrations
+
+import "embed"
+
+// FS содержит SQL-миграции схемы базы данных, встроенные в исполняемый файл.
+// Новые миграции добавляются парой файлов <версия>_<имя>.up.sql и <версия>_<имя>.down.sql.
+//
+//go:embed *.sql
+var FS embed.FS
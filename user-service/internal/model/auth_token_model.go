@@ -0,0 +1,8 @@
+package model
+
+type AuthTokenModel struct {
+	AccessToken      string
+	ExpiresAt        int64
+	RefreshToken     string
+	RefreshExpiresAt int64
+}
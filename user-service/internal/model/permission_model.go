@@ -0,0 +1,7 @@
+package model
+
+type PermissionModel struct {
+	Id          int
+	Name        string
+	Description string
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type RefreshTokenModel struct {
+	Id        int
+	UserId    int
+	TokenHash string
+	FamilyId  string
+	ExpiresAt time.Time
+	Used      bool
+	Revoked   bool
+}
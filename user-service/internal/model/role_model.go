@@ -0,0 +1,8 @@
+package model
+
+type RoleModel struct {
+	Id          int
+	Name        string
+	Description string
+	Permissions []string
+}
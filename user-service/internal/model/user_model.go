@@ -1,7 +1,13 @@
 package model
 
+import "time"
+
 type UserModel struct {
 	Id            int
 	Username      string
 	Password_Hash string
+	Tenant        string
+	Version       int       // Увеличивается при каждом изменении; 0 в запросе на изменение — без проверки версии
+	UpdatedAt     time.Time // Время последнего изменения
+	CreatedAt     time.Time // Время создания
 }
@@ -0,0 +1,68 @@
+package http_controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"user-service/internal/dto"
+	"user-service/internal/http_response"
+	"user-service/internal/mapper"
+	"user-service/internal/messages"
+	"user-service/internal/middleware"
+	"user-service/internal/policy"
+
+	"github.com/go-playground/validator"
+)
+
+type policyControllerImpl struct {
+	policyService policy.PolicyService
+	policyMapper  mapper.PolicyMapper
+}
+
+// Explain implements policy.PolicyController.
+// Вычисляет решение без выполнения действия и объясняет, какие правила применились и почему.
+func (policyController *policyControllerImpl) Explain() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "PolicyController.Explain:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			var dtoRequest dto.ExplainRequest
+			if !http_response.ReadRequest(responseWriter, request, handlerName, &dtoRequest, "dto.ExplainRequest") {
+				return
+			}
+
+			if err := validator.New().Struct(dtoRequest); err != nil {
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusBadRequest, err, messages.RequestInvalid, "dto.ExplainRequest")
+				return
+			}
+
+			payload, err := middleware.GetPayload(request)
+			if err != nil {
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusInternalServerError, err, messages.AuthPayloadFailed)
+				return
+			}
+			if dtoRequest.Subject != nil {
+				payload = policyController.policyMapper.SubjectToPayload(*dtoRequest.Subject)
+			}
+
+			decision, err := policyController.policyService.Decide(request.Context(), payload, dtoRequest.Action, dtoRequest.ResourceId)
+			if err != nil {
+				if errors.Is(err, policy.ErrResourceNotFound) {
+					http_response.WriteError(responseWriter, request, handlerName, http.StatusNotFound, err, messages.PolicyResourceNotFound)
+					return
+				}
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusInternalServerError, err, messages.PolicyDecideFailed)
+				return
+			}
+
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusOK, policyController.policyMapper.ToDto(*decision))
+		},
+	)
+}
+
+func NewPolicyController(policyService policy.PolicyService) policy.PolicyController {
+	return &policyControllerImpl{
+		policyService: policyService,
+	}
+}
@@ -0,0 +1,134 @@
+package http_controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"user-service/internal/dto"
+	"user-service/internal/middleware"
+	"user-service/internal/policy"
+	"user-service/internal/policy/mock"
+	"user-service/pkg/abac"
+	"user-service/pkg/jwt"
+	"user-service/pkg/jwt/jwt_metadata"
+	"user-service/pkg/problem"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/gorilla/mux"
+)
+
+const testPolicies string = `
+rules:
+  - name: owner
+    effect: allow
+    actions: [users:read]
+    conditions:
+      - attribute: subject.id
+        operator: eq
+        value_from: resource.id
+`
+
+var engine *abac.Engine
+
+var mockService *mock.MockPolicyService = &mock.MockPolicyService{
+	DecideFunc: func(ctx context.Context, payload *jwt_metadata.Payload, action string, resourceId int) (*abac.Decision, error) {
+		if resourceId != 1 {
+			return nil, policy.ErrResourceNotFound
+		}
+		decision := engine.Evaluate(abac.Request{
+			Subject:  abac.Attributes{"id": payload.Subject},
+			Action:   action,
+			Resource: abac.Attributes{"id": resourceId},
+		})
+		return &decision, nil
+	},
+}
+
+var policyController policy.PolicyController = NewPolicyController(mockService)
+var policyRouter *mux.Router = mux.NewRouter()
+
+var adminToken string
+
+func init() {
+	var err error
+	engine, err = abac.Parse([]byte(testPolicies))
+	if err != nil {
+		panic(err)
+	}
+
+	policyRouter.Use(middleware.Localize)
+	policyRouter = policy.SetupPolicyRoutes(policyRouter, policyController)
+	jwtCoder := jwt.NewJWTCoder(middleware.Alg, middleware.Secret, middleware.Issuer, middleware.Audience, middleware.ExpirationTimeDuration)
+	strToken, err := jwtCoder.Encode(*jwtCoder.NewToken("1", []string{"admin"}))
+	if err != nil {
+		panic(err)
+	}
+	adminToken = *strToken
+}
+
+func explain(t *testing.T, body string) (*httptest.ResponseRecorder, dto.ExplainResponse) {
+	req := httptest.NewRequest(http.MethodPost, "/policies/explain", strings.NewReader(body))
+	req.Header.Add("Authorization", "Bearer "+adminToken)
+	res := httptest.NewRecorder()
+	policyRouter.ServeHTTP(res, req)
+
+	var dtoResponse dto.ExplainResponse
+	if res.Code == http.StatusOK {
+		if err := json.NewDecoder(res.Body).Decode(&dtoResponse); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res, dtoResponse
+}
+
+func TestExplainHandler(t *testing.T) {
+	t.Run("Empty Body", func(t *testing.T) {
+		res, _ := explain(t, "")
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		res, _ := explain(t, "{\"action\": \"users:read\"}")
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Resource not found", func(t *testing.T) {
+		res, _ := explain(t, "{\"action\": \"users:read\", \"resource_id\": 100}")
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	})
+
+	t.Run("Accept-Language en", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/policies/explain", strings.NewReader("{\"action\": \"users:read\", \"resource_id\": 100}"))
+		req.Header.Add("Authorization", "Bearer "+adminToken)
+		req.Header.Add("Accept-Language", "en")
+		res := httptest.NewRecorder()
+		policyRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNotFound)
+
+		var problemDetails problem.Problem
+		if err := json.NewDecoder(res.Body).Decode(&problemDetails); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, problemDetails.Detail, "No user with the given id exists!")
+	})
+
+	t.Run("Allowed for caller", func(t *testing.T) {
+		res, dtoResponse := explain(t, "{\"action\": \"users:read\", \"resource_id\": 1}")
+		assert.Equal(t, res.Code, http.StatusOK)
+		assert.Equal(t, dtoResponse.Allowed, true)
+		assert.Equal(t, dtoResponse.Rule, "owner")
+	})
+
+	t.Run("Denied for given subject", func(t *testing.T) {
+		res, dtoResponse := explain(t, "{\"action\": \"users:read\", \"resource_id\": 1, \"subject\": {\"sub\": \"2\"}}")
+		assert.Equal(t, res.Code, http.StatusOK)
+		assert.Equal(t, dtoResponse.Allowed, false)
+		assert.Equal(t, len(dtoResponse.Rules), 1)
+		assert.Equal(t, dtoResponse.Rules[0].Matched, false)
+		assert.Equal(t, dtoResponse.Rules[0].Reasons[0], "subject.id (2) eq resource.id (1): не выполнено")
+	})
+}
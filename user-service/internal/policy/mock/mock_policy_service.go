@@ -0,0 +1,17 @@
+package mock
+
+import (
+	"context"
+	"user-service/pkg/abac"
+	"user-service/pkg/jwt/jwt_metadata"
+)
+
+// Создаем мок-реализацию
+type MockPolicyService struct {
+	DecideFunc func(ctx context.Context, payload *jwt_metadata.Payload, action string, resourceId int) (*abac.Decision, error)
+}
+
+// Decide implements policy.PolicyService.
+func (m *MockPolicyService) Decide(ctx context.Context, payload *jwt_metadata.Payload, action string, resourceId int) (*abac.Decision, error) {
+	return m.DecideFunc(ctx, payload, action, resourceId)
+}
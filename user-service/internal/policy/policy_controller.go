@@ -0,0 +1,20 @@
+package policy
+
+import (
+	"net/http"
+	"user-service/internal/middleware"
+
+	"github.com/gorilla/mux"
+)
+
+type PolicyController interface {
+	Explain() http.Handler
+}
+
+func SetupPolicyRoutes(router *mux.Router, policyController PolicyController) *mux.Router {
+
+	postRoutes := router.Methods(http.MethodPost).Subrouter()
+	postRoutes.Handle("/policies/explain", middleware.IsAdminMiddleware(policyController.Explain()))
+
+	return router
+}
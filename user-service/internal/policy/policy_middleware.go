@@ -0,0 +1,37 @@
+package policy
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"user-service/internal/middleware"
+	"user-service/pkg/jwt/jwt_metadata"
+
+	"github.com/gorilla/mux"
+)
+
+// Require возвращает политику маршрута, которая разрешает действие action
+// над пользователем из параметра пути routeParam по правилам ABAC.
+// Ошибка вычисления решения считается запретом.
+func Require(policyService PolicyService, action, routeParam string) middleware.Policy {
+	return RequireAction(policyService, func(request *http.Request) string { return action }, routeParam)
+}
+
+// RequireAction — то же, что Require, но действие выбирается по запросу функцией action,
+// например в зависимости от изменяемых полей. Если пользователь не найден, действует решение
+// по атрибутам, известным без записи, чтобы обработчик ответил 404 тем, кому это разрешено.
+func RequireAction(policyService PolicyService, action func(request *http.Request) string, routeParam string) middleware.Policy {
+	return func(request *http.Request, payload *jwt_metadata.Payload) bool {
+		resourceId, err := strconv.Atoi(mux.Vars(request)[routeParam])
+		if err != nil {
+			return false
+		}
+		decision, err := policyService.Decide(request.Context(), payload, action(request), resourceId)
+		if err != nil && !errors.Is(err, ErrResourceNotFound) {
+			log.Println("policy.Require:", request.URL.Path, "from", request.Host, "Ошибка вычисления решения!", err)
+			return false
+		}
+		return decision != nil && decision.Allowed
+	}
+}
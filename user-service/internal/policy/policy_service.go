@@ -0,0 +1,18 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"user-service/pkg/abac"
+	"user-service/pkg/jwt/jwt_metadata"
+)
+
+// ErrResourceNotFound возвращается, если пользователь, к которому относится действие, не найден
+var ErrResourceNotFound error = errors.New("ресурс не найден")
+
+type PolicyService interface {
+	// Decide вычисляет решение для субъекта payload, выполняющего действие action
+	// над пользователем с идентификатором resourceId. Если пользователь не найден, возвращается
+	// ErrResourceNotFound вместе с решением по атрибутам, известным без записи (resource.id).
+	Decide(ctx context.Context, payload *jwt_metadata.Payload, action string, resourceId int) (*abac.Decision, error)
+}
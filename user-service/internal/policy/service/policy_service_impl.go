@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"user-service/internal/model"
+	"user-service/internal/policy"
+	"user-service/internal/user"
+	"user-service/pkg/abac"
+	"user-service/pkg/jwt/jwt_metadata"
+)
+
+type policyServiceImpl struct {
+	engine         *abac.Engine
+	userRepository user.UserRepository
+}
+
+// Decide implements policy.PolicyService.
+func (policyService *policyServiceImpl) Decide(ctx context.Context, payload *jwt_metadata.Payload, action string, resourceId int) (*abac.Decision, error) {
+	userModel, err := policyService.userRepository.GetOne(ctx, resourceId)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			// Правила, не зависящие от атрибутов записи, например для администратора, по-прежнему применимы
+			decision := policyService.engine.Evaluate(abac.Request{
+				Subject:  subjectAttributes(payload),
+				Action:   action,
+				Resource: abac.Attributes{"id": resourceId},
+			})
+			return &decision, policy.ErrResourceNotFound
+		}
+		return nil, err
+	}
+
+	decision := policyService.engine.Evaluate(abac.Request{
+		Subject:  subjectAttributes(payload),
+		Action:   action,
+		Resource: resourceAttributes(userModel),
+	})
+	return &decision, nil
+}
+
+// subjectAttributes возвращает атрибуты субъекта из утверждений токена
+func subjectAttributes(payload *jwt_metadata.Payload) abac.Attributes {
+	attributes := abac.Attributes{
+		"id":          payload.Subject,
+		"roles":       payload.Roles,
+		"permissions": payload.Permissions,
+	}
+	setTenant(attributes, payload.Tenant)
+	return attributes
+}
+
+// resourceAttributes возвращает атрибуты пользователя, над которым выполняется действие
+func resourceAttributes(userModel *model.UserModel) abac.Attributes {
+	attributes := abac.Attributes{
+		"id":       userModel.Id,
+		"username": userModel.Username,
+	}
+	setTenant(attributes, userModel.Tenant)
+	return attributes
+}
+
+// setTenant задает атрибут tenant, только если организация назначена. Условие на незаданный
+// атрибут не выполняется, поэтому два пользователя без организации не считаются одной организацией.
+func setTenant(attributes abac.Attributes, tenant string) {
+	if tenant != "" {
+		attributes["tenant"] = tenant
+	}
+}
+
+func NewPolicyService(engine *abac.Engine, userRepository user.UserRepository) policy.PolicyService {
+	return &policyServiceImpl{
+		engine:         engine,
+		userRepository: userRepository,
+	}
+}
@@ -0,0 +1,399 @@
+package http_controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"user-service/internal/dto"
+	"user-service/internal/http_response"
+	"user-service/internal/mapper"
+	"user-service/internal/messages"
+	"user-service/internal/rbac"
+	"user-service/pkg/problem"
+)
+
+// Типы ошибок RFC 7807, которые возвращает контроллер ролей и прав
+const (
+	problemTypeRoleNotFound       string = "/problems/role-not-found"
+	problemTypePermissionNotFound string = "/problems/permission-not-found"
+	problemTypeUserNotFound       string = "/problems/user-not-found"
+	problemTypeRoleNotAssigned    string = "/problems/role-not-assigned"
+	problemTypeRoleExists         string = "/problems/role-already-exists"
+	problemTypePermissionExists   string = "/problems/permission-already-exists"
+)
+
+type rbacControllerImpl struct {
+	rbacService rbac.RBACService
+	rbacMapper  mapper.RBACMapper
+}
+
+// CreateRole implements rbac.RBACController.
+func (rbacController *rbacControllerImpl) CreateRole() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "RBACController.CreateRole:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			var dtoRequest dto.RoleRequest
+			if !http_response.ReadRequest(responseWriter, request, handlerName, &dtoRequest, "dto.RoleRequest") {
+				return
+			}
+
+			roleModel, err := rbacController.rbacMapper.RoleToModel(dtoRequest)
+			if err != nil {
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusBadRequest, err, messages.RBACRoleConvertFailed)
+				return
+			}
+
+			roleModel, err = rbacController.rbacService.CreateRole(request.Context(), roleModel)
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.RBACCreateRoleFailed, err)
+				return
+			}
+
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusCreated, rbacController.rbacMapper.RoleToDto(*roleModel))
+		},
+	)
+}
+
+// GetRoles implements rbac.RBACController.
+func (rbacController *rbacControllerImpl) GetRoles() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "RBACController.GetRoles:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			models, err := rbacController.rbacService.GetRoles(request.Context())
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.RBACGetRolesFailed, err)
+				return
+			}
+
+			var dtoResponses []dto.RoleResponse = make([]dto.RoleResponse, 0)
+			for _, value := range models {
+				dtoResponses = append(dtoResponses, *rbacController.rbacMapper.RoleToDto(*value))
+			}
+
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusOK, dtoResponses)
+		},
+	)
+}
+
+// GetRole implements rbac.RBACController.
+func (rbacController *rbacControllerImpl) GetRole() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "RBACController.GetRole:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			id, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "id")
+			if !ok {
+				return
+			}
+
+			roleModel, err := rbacController.rbacService.GetRole(request.Context(), id)
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.RBACGetRoleFailed, err)
+				return
+			}
+
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusOK, rbacController.rbacMapper.RoleToDto(*roleModel))
+		},
+	)
+}
+
+// UpdateRole implements rbac.RBACController.
+func (rbacController *rbacControllerImpl) UpdateRole() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "RBACController.UpdateRole:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			id, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "id")
+			if !ok {
+				return
+			}
+
+			var dtoRequest dto.RoleRequest
+			if !http_response.ReadRequest(responseWriter, request, handlerName, &dtoRequest, "dto.RoleRequest") {
+				return
+			}
+
+			roleModel, err := rbacController.rbacMapper.RoleToModel(dtoRequest)
+			if err != nil {
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusBadRequest, err, messages.RBACRoleConvertFailed)
+				return
+			}
+
+			roleModel, err = rbacController.rbacService.UpdateRole(request.Context(), id, roleModel)
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.RBACUpdateRoleFailed, err)
+				return
+			}
+
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusOK, rbacController.rbacMapper.RoleToDto(*roleModel))
+		},
+	)
+}
+
+// DeleteRole implements rbac.RBACController.
+func (rbacController *rbacControllerImpl) DeleteRole() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "RBACController.DeleteRole:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			id, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "id")
+			if !ok {
+				return
+			}
+
+			if err := rbacController.rbacService.DeleteRole(request.Context(), id); err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.RBACDeleteRoleFailed, err)
+				return
+			}
+
+			responseWriter.WriteHeader(http.StatusNoContent)
+			log.Println(handlerName, request.URL.Path, "from", request.Host, "role deleted:", id)
+		},
+	)
+}
+
+// CreatePermission implements rbac.RBACController.
+func (rbacController *rbacControllerImpl) CreatePermission() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "RBACController.CreatePermission:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			var dtoRequest dto.PermissionRequest
+			if !http_response.ReadRequest(responseWriter, request, handlerName, &dtoRequest, "dto.PermissionRequest") {
+				return
+			}
+
+			permissionModel, err := rbacController.rbacMapper.PermissionToModel(dtoRequest)
+			if err != nil {
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusBadRequest, err, messages.RBACPermissionConvertFailed)
+				return
+			}
+
+			permissionModel, err = rbacController.rbacService.CreatePermission(request.Context(), permissionModel)
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.RBACCreatePermissionFailed, err)
+				return
+			}
+
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusCreated, rbacController.rbacMapper.PermissionToDto(*permissionModel))
+		},
+	)
+}
+
+// GetPermissions implements rbac.RBACController.
+func (rbacController *rbacControllerImpl) GetPermissions() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "RBACController.GetPermissions:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			models, err := rbacController.rbacService.GetPermissions(request.Context())
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.RBACGetPermissionsFailed, err)
+				return
+			}
+
+			var dtoResponses []dto.PermissionResponse = make([]dto.PermissionResponse, 0)
+			for _, value := range models {
+				dtoResponses = append(dtoResponses, *rbacController.rbacMapper.PermissionToDto(*value))
+			}
+
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusOK, dtoResponses)
+		},
+	)
+}
+
+// GetPermission implements rbac.RBACController.
+func (rbacController *rbacControllerImpl) GetPermission() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "RBACController.GetPermission:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			id, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "id")
+			if !ok {
+				return
+			}
+
+			permissionModel, err := rbacController.rbacService.GetPermission(request.Context(), id)
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.RBACGetPermissionFailed, err)
+				return
+			}
+
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusOK, rbacController.rbacMapper.PermissionToDto(*permissionModel))
+		},
+	)
+}
+
+// UpdatePermission implements rbac.RBACController.
+func (rbacController *rbacControllerImpl) UpdatePermission() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "RBACController.UpdatePermission:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			id, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "id")
+			if !ok {
+				return
+			}
+
+			var dtoRequest dto.PermissionRequest
+			if !http_response.ReadRequest(responseWriter, request, handlerName, &dtoRequest, "dto.PermissionRequest") {
+				return
+			}
+
+			permissionModel, err := rbacController.rbacMapper.PermissionToModel(dtoRequest)
+			if err != nil {
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusBadRequest, err, messages.RBACPermissionConvertFailed)
+				return
+			}
+
+			permissionModel, err = rbacController.rbacService.UpdatePermission(request.Context(), id, permissionModel)
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.RBACUpdatePermissionFailed, err)
+				return
+			}
+
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusOK, rbacController.rbacMapper.PermissionToDto(*permissionModel))
+		},
+	)
+}
+
+// DeletePermission implements rbac.RBACController.
+func (rbacController *rbacControllerImpl) DeletePermission() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "RBACController.DeletePermission:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			id, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "id")
+			if !ok {
+				return
+			}
+
+			if err := rbacController.rbacService.DeletePermission(request.Context(), id); err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.RBACDeletePermissionFailed, err)
+				return
+			}
+
+			responseWriter.WriteHeader(http.StatusNoContent)
+			log.Println(handlerName, request.URL.Path, "from", request.Host, "permission deleted:", id)
+		},
+	)
+}
+
+// GetUserRoles implements rbac.RBACController.
+func (rbacController *rbacControllerImpl) GetUserRoles() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "RBACController.GetUserRoles:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			userId, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "id")
+			if !ok {
+				return
+			}
+
+			roles, permissions, err := rbacController.rbacService.GetUserRoles(request.Context(), userId)
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.RBACGetUserRolesFailed, err)
+				return
+			}
+
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusOK, rbacController.rbacMapper.UserRolesToDto(userId, roles, permissions))
+		},
+	)
+}
+
+// AssignRole implements rbac.RBACController.
+func (rbacController *rbacControllerImpl) AssignRole() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "RBACController.AssignRole:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			userId, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "id")
+			if !ok {
+				return
+			}
+			roleId, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "roleId")
+			if !ok {
+				return
+			}
+
+			if err := rbacController.rbacService.AssignRole(request.Context(), userId, roleId); err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.RBACAssignRoleFailed, err)
+				return
+			}
+
+			responseWriter.WriteHeader(http.StatusNoContent)
+			log.Println(handlerName, request.URL.Path, "from", request.Host, "role", roleId, "assigned to user", userId)
+		},
+	)
+}
+
+// UnassignRole implements rbac.RBACController.
+func (rbacController *rbacControllerImpl) UnassignRole() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "RBACController.UnassignRole:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			userId, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "id")
+			if !ok {
+				return
+			}
+			roleId, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "roleId")
+			if !ok {
+				return
+			}
+
+			if err := rbacController.rbacService.UnassignRole(request.Context(), userId, roleId); err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.RBACUnassignRoleFailed, err)
+				return
+			}
+
+			responseWriter.WriteHeader(http.StatusNoContent)
+			log.Println(handlerName, request.URL.Path, "from", request.Host, "role", roleId, "unassigned from user", userId)
+		},
+	)
+}
+
+// writeServiceError сопоставляет ошибку сервиса с кодом ответа и типом ошибки.
+// Сообщение messageKey описывает неудавшуюся операцию; неизвестные ошибки считаются внутренними.
+func writeServiceError(responseWriter http.ResponseWriter, request *http.Request, handlerName string, messageKey string, err error) {
+	ctx := request.Context()
+	var problemDetails *problem.Problem
+	switch {
+	case errors.Is(err, rbac.ErrRoleNotFound):
+		problemDetails = http_response.NewProblem(ctx, problemTypeRoleNotFound, http.StatusNotFound, messages.RBACRoleNotFound, messageKey)
+	case errors.Is(err, rbac.ErrPermissionNotFound):
+		problemDetails = http_response.NewProblem(ctx, problemTypePermissionNotFound, http.StatusNotFound, messages.RBACPermissionNotFound, messageKey)
+	case errors.Is(err, rbac.ErrUserNotFound):
+		problemDetails = http_response.NewProblem(ctx, problemTypeUserNotFound, http.StatusNotFound, messages.UserNotFound, messageKey)
+	case errors.Is(err, rbac.ErrRoleNotAssigned):
+		problemDetails = http_response.NewProblem(ctx, problemTypeRoleNotAssigned, http.StatusNotFound, messages.RBACRoleNotAssigned, messageKey)
+	case errors.Is(err, rbac.ErrRoleAlreadyExists):
+		problemDetails = http_response.NewProblem(ctx, problemTypeRoleExists, http.StatusConflict, messages.RBACRoleAlreadyExists, messageKey)
+	case errors.Is(err, rbac.ErrPermissionAlreadyExists):
+		problemDetails = http_response.NewProblem(ctx, problemTypePermissionExists, http.StatusConflict, messages.RBACPermissionAlreadyExists, messageKey)
+	default:
+		problemDetails = problem.New(http.StatusInternalServerError, messages.Get(ctx, messageKey))
+	}
+	http_response.WriteProblem(responseWriter, request, handlerName, problemDetails, err)
+}
+
+func NewRBACController(rbacService rbac.RBACService) rbac.RBACController {
+	return &rbacControllerImpl{
+		rbacService: rbacService,
+	}
+}
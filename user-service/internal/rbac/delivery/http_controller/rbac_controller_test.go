@@ -0,0 +1,280 @@
+package http_controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"user-service/internal/dto"
+	"user-service/internal/middleware"
+	"user-service/internal/model"
+	"user-service/internal/rbac"
+	"user-service/internal/rbac/mock"
+	"user-service/pkg/jwt"
+	"user-service/pkg/problem"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/gorilla/mux"
+)
+
+const jsonRoleTemplate string = "{\"name\": \"%s\", \"description\": \"%s\", \"permissions\": [%s]}"
+const jsonPermissionTemplate string = "{\"name\": \"%s\", \"description\": \"%s\"}"
+
+const (
+	mockRoleId       int    = 1
+	mockRoleName     string = "editor"
+	mockPermissionId int    = 1
+	mockUserId       int    = 1
+)
+
+var mockRoles = map[int]*model.RoleModel{
+	mockRoleId: {Id: mockRoleId, Name: mockRoleName, Permissions: []string{"users:read", "users:write"}},
+}
+
+var mockService *mock.MockRBACService = &mock.MockRBACService{
+	CreateRoleFunc: func(ctx context.Context, roleModel *model.RoleModel) (*model.RoleModel, error) {
+		if roleModel.Name == mockRoleName {
+			return nil, rbac.ErrRoleAlreadyExists
+		}
+		for _, permission := range roleModel.Permissions {
+			if !strings.HasPrefix(permission, "users:") {
+				return nil, rbac.ErrPermissionNotFound
+			}
+		}
+		roleModel.Id = 2
+		return roleModel, nil
+	},
+	GetRolesFunc: func(ctx context.Context) ([]*model.RoleModel, error) {
+		return []*model.RoleModel{mockRoles[mockRoleId]}, nil
+	},
+	GetRoleFunc: func(ctx context.Context, id int) (*model.RoleModel, error) {
+		if roleModel, ok := mockRoles[id]; ok {
+			return roleModel, nil
+		}
+		return nil, rbac.ErrRoleNotFound
+	},
+	DeleteRoleFunc: func(ctx context.Context, id int) error {
+		if _, ok := mockRoles[id]; ok {
+			return nil
+		}
+		return rbac.ErrRoleNotFound
+	},
+	CreatePermissionFunc: func(ctx context.Context, permissionModel *model.PermissionModel) (*model.PermissionModel, error) {
+		permissionModel.Id = mockPermissionId
+		return permissionModel, nil
+	},
+	GetUserRolesFunc: func(ctx context.Context, userId int) ([]string, []string, error) {
+		if userId != mockUserId {
+			return nil, nil, rbac.ErrUserNotFound
+		}
+		return []string{"admin"}, []string{"users:delete", "users:read"}, nil
+	},
+	AssignRoleFunc: func(ctx context.Context, userId, roleId int) error {
+		if _, ok := mockRoles[roleId]; !ok {
+			return rbac.ErrRoleNotFound
+		}
+		return nil
+	},
+	UnassignRoleFunc: func(ctx context.Context, userId, roleId int) error {
+		return rbac.ErrRoleNotAssigned
+	},
+}
+
+var rbacController rbac.RBACController = NewRBACController(mockService)
+var rbacRouter *mux.Router = mux.NewRouter()
+
+var adminToken string
+var userToken string
+
+func init() {
+	rbacRouter.Use(middleware.Localize)
+	rbacRouter = rbac.SetupRBACRoutes(rbacRouter, rbacController)
+	jwtCoder := jwt.NewJWTCoder(middleware.Alg, middleware.Secret, middleware.Issuer, middleware.Audience, middleware.ExpirationTimeDuration)
+	adminToken = encodeToken(jwtCoder, "1", "admin")
+	userToken = encodeToken(jwtCoder, "2", "user")
+}
+
+func encodeToken(jwtCoder *jwt.JWTCoder, subject, role string) string {
+	strToken, err := jwtCoder.Encode(*jwtCoder.NewToken(subject, []string{role}))
+	if err != nil {
+		panic(err)
+	}
+	return *strToken
+}
+
+func newRequest(method, target, token string, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestAdminOnly(t *testing.T) {
+	t.Run("Without token", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodGet, "/roles", "", ""))
+		assert.Equal(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Not admin", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodGet, "/roles", userToken, ""))
+		assert.Equal(t, res.Code, http.StatusForbidden)
+	})
+}
+
+func TestCreateRoleHandler(t *testing.T) {
+	t.Run("Empty Body", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodPost, "/roles", adminToken, ""))
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodPost, "/roles", adminToken, "{}"))
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Already exists", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRoleTemplate, mockRoleName, "", "")
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodPost, "/roles", adminToken, jsonBody))
+		assert.Equal(t, res.Code, http.StatusConflict)
+	})
+
+	t.Run("Accept-Language en", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRoleTemplate, mockRoleName, "", "")
+		req := newRequest(http.MethodPost, "/roles", adminToken, jsonBody)
+		req.Header.Add("Accept-Language", "en")
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusConflict)
+
+		var problemDetails problem.Problem
+		if err := json.NewDecoder(res.Body).Decode(&problemDetails); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, problemDetails.Type, problemTypeRoleExists)
+		assert.Equal(t, problemDetails.Title, "A role with this name already exists")
+		assert.Equal(t, problemDetails.Detail, "Failed to create the role!")
+	})
+
+	t.Run("Unknown permission", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRoleTemplate, "moderator", "", "\"posts:delete\"")
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodPost, "/roles", adminToken, jsonBody))
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	})
+
+	t.Run("Created", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRoleTemplate, "moderator", "Модератор", "\"users:write\", \"users:read\", \"users:write\"")
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodPost, "/roles", adminToken, jsonBody))
+		assert.Equal(t, res.Code, http.StatusCreated)
+
+		var dtoResponse dto.RoleResponse
+		if err := json.NewDecoder(res.Body).Decode(&dtoResponse); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, dtoResponse.Name, "moderator")
+		assert.Equal(t, dtoResponse.Permissions, []string{"users:read", "users:write"})
+	})
+}
+
+func TestGetRoleHandler(t *testing.T) {
+	t.Run("Not found", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodGet, "/roles/100", adminToken, ""))
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	})
+
+	t.Run("Found", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodGet, fmt.Sprintf("/roles/%d", mockRoleId), adminToken, ""))
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponse dto.RoleResponse
+		if err := json.NewDecoder(res.Body).Decode(&dtoResponse); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, dtoResponse.Name, mockRoleName)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodGet, "/roles", adminToken, ""))
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponses []dto.RoleResponse
+		if err := json.NewDecoder(res.Body).Decode(&dtoResponses); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, len(dtoResponses), 1)
+	})
+}
+
+func TestDeleteRoleHandler(t *testing.T) {
+	t.Run("Not found", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodDelete, "/roles/100", adminToken, ""))
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodDelete, fmt.Sprintf("/roles/%d", mockRoleId), adminToken, ""))
+		assert.Equal(t, res.Code, http.StatusNoContent)
+	})
+}
+
+func TestCreatePermissionHandler(t *testing.T) {
+	jsonBody := fmt.Sprintf(jsonPermissionTemplate, "users:export", "Выгрузка пользователей")
+	res := httptest.NewRecorder()
+	rbacRouter.ServeHTTP(res, newRequest(http.MethodPost, "/permissions", adminToken, jsonBody))
+	assert.Equal(t, res.Code, http.StatusCreated)
+}
+
+func TestUserRolesHandlers(t *testing.T) {
+	t.Run("Unknown user", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodGet, "/users/100/roles", adminToken, ""))
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	})
+
+	t.Run("Get roles", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodGet, fmt.Sprintf("/users/%d/roles", mockUserId), adminToken, ""))
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponse dto.UserRolesResponse
+		if err := json.NewDecoder(res.Body).Decode(&dtoResponse); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, dtoResponse.Roles, []string{"admin"})
+		assert.Equal(t, dtoResponse.Permissions, []string{"users:delete", "users:read"})
+	})
+
+	t.Run("Assign unknown role", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodPut, fmt.Sprintf("/users/%d/roles/100", mockUserId), adminToken, ""))
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	})
+
+	t.Run("Assign role", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodPut, fmt.Sprintf("/users/%d/roles/%d", mockUserId, mockRoleId), adminToken, ""))
+		assert.Equal(t, res.Code, http.StatusNoContent)
+	})
+
+	t.Run("Unassign not assigned role", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		rbacRouter.ServeHTTP(res, newRequest(http.MethodDelete, fmt.Sprintf("/users/%d/roles/%d", mockUserId, mockRoleId), adminToken, ""))
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,88 @@
+package mock
+
+import (
+	"context"
+	"user-service/internal/model"
+)
+
+// Создаем мок-реализацию
+type MockRBACService struct {
+	CreateRoleFunc       func(ctx context.Context, roleModel *model.RoleModel) (*model.RoleModel, error)
+	GetRolesFunc         func(ctx context.Context) ([]*model.RoleModel, error)
+	GetRoleFunc          func(ctx context.Context, id int) (*model.RoleModel, error)
+	UpdateRoleFunc       func(ctx context.Context, id int, roleModel *model.RoleModel) (*model.RoleModel, error)
+	DeleteRoleFunc       func(ctx context.Context, id int) error
+	CreatePermissionFunc func(ctx context.Context, permissionModel *model.PermissionModel) (*model.PermissionModel, error)
+	GetPermissionsFunc   func(ctx context.Context) ([]*model.PermissionModel, error)
+	GetPermissionFunc    func(ctx context.Context, id int) (*model.PermissionModel, error)
+	UpdatePermissionFunc func(ctx context.Context, id int, permissionModel *model.PermissionModel) (*model.PermissionModel, error)
+	DeletePermissionFunc func(ctx context.Context, id int) error
+	GetUserRolesFunc     func(ctx context.Context, userId int) ([]string, []string, error)
+	AssignRoleFunc       func(ctx context.Context, userId, roleId int) error
+	UnassignRoleFunc     func(ctx context.Context, userId, roleId int) error
+}
+
+// CreateRole implements rbac.RBACService.
+func (m *MockRBACService) CreateRole(ctx context.Context, roleModel *model.RoleModel) (*model.RoleModel, error) {
+	return m.CreateRoleFunc(ctx, roleModel)
+}
+
+// GetRoles implements rbac.RBACService.
+func (m *MockRBACService) GetRoles(ctx context.Context) ([]*model.RoleModel, error) {
+	return m.GetRolesFunc(ctx)
+}
+
+// GetRole implements rbac.RBACService.
+func (m *MockRBACService) GetRole(ctx context.Context, id int) (*model.RoleModel, error) {
+	return m.GetRoleFunc(ctx, id)
+}
+
+// UpdateRole implements rbac.RBACService.
+func (m *MockRBACService) UpdateRole(ctx context.Context, id int, roleModel *model.RoleModel) (*model.RoleModel, error) {
+	return m.UpdateRoleFunc(ctx, id, roleModel)
+}
+
+// DeleteRole implements rbac.RBACService.
+func (m *MockRBACService) DeleteRole(ctx context.Context, id int) error {
+	return m.DeleteRoleFunc(ctx, id)
+}
+
+// CreatePermission implements rbac.RBACService.
+func (m *MockRBACService) CreatePermission(ctx context.Context, permissionModel *model.PermissionModel) (*model.PermissionModel, error) {
+	return m.CreatePermissionFunc(ctx, permissionModel)
+}
+
+// GetPermissions implements rbac.RBACService.
+func (m *MockRBACService) GetPermissions(ctx context.Context) ([]*model.PermissionModel, error) {
+	return m.GetPermissionsFunc(ctx)
+}
+
+// GetPermission implements rbac.RBACService.
+func (m *MockRBACService) GetPermission(ctx context.Context, id int) (*model.PermissionModel, error) {
+	return m.GetPermissionFunc(ctx, id)
+}
+
+// UpdatePermission implements rbac.RBACService.
+func (m *MockRBACService) UpdatePermission(ctx context.Context, id int, permissionModel *model.PermissionModel) (*model.PermissionModel, error) {
+	return m.UpdatePermissionFunc(ctx, id, permissionModel)
+}
+
+// DeletePermission implements rbac.RBACService.
+func (m *MockRBACService) DeletePermission(ctx context.Context, id int) error {
+	return m.DeletePermissionFunc(ctx, id)
+}
+
+// GetUserRoles implements rbac.RBACService.
+func (m *MockRBACService) GetUserRoles(ctx context.Context, userId int) ([]string, []string, error) {
+	return m.GetUserRolesFunc(ctx, userId)
+}
+
+// AssignRole implements rbac.RBACService.
+func (m *MockRBACService) AssignRole(ctx context.Context, userId, roleId int) error {
+	return m.AssignRoleFunc(ctx, userId, roleId)
+}
+
+// UnassignRole implements rbac.RBACService.
+func (m *MockRBACService) UnassignRole(ctx context.Context, userId, roleId int) error {
+	return m.UnassignRoleFunc(ctx, userId, roleId)
+}
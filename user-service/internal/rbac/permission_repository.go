@@ -0,0 +1,14 @@
+package rbac
+
+import (
+	"context"
+	"user-service/internal/model"
+)
+
+type PermissionRepository interface {
+	Create(ctx context.Context, permissionModel *model.PermissionModel) (*model.PermissionModel, error)
+	GetAll(ctx context.Context) ([]*model.PermissionModel, error)
+	GetOne(ctx context.Context, id int) (*model.PermissionModel, error)
+	Update(ctx context.Context, id int, permissionModel *model.PermissionModel) (*model.PermissionModel, error)
+	Delete(ctx context.Context, id int) error
+}
@@ -0,0 +1,52 @@
+package rbac
+
+import (
+	"net/http"
+	"user-service/internal/middleware"
+
+	"github.com/gorilla/mux"
+)
+
+type RBACController interface {
+	CreateRole() http.Handler
+	GetRoles() http.Handler
+	GetRole() http.Handler
+	UpdateRole() http.Handler
+	DeleteRole() http.Handler
+
+	CreatePermission() http.Handler
+	GetPermissions() http.Handler
+	GetPermission() http.Handler
+	UpdatePermission() http.Handler
+	DeletePermission() http.Handler
+
+	GetUserRoles() http.Handler
+	AssignRole() http.Handler
+	UnassignRole() http.Handler
+}
+
+func SetupRBACRoutes(router *mux.Router, rbacController RBACController) *mux.Router {
+
+	postRoutes := router.Methods(http.MethodPost).Subrouter()
+	postRoutes.Handle("/roles", middleware.IsAdminMiddleware(rbacController.CreateRole()))
+	postRoutes.Handle("/permissions", middleware.IsAdminMiddleware(rbacController.CreatePermission()))
+
+	getRoutes := router.Methods(http.MethodGet).Subrouter()
+	getRoutes.Handle("/roles", middleware.IsAdminMiddleware(rbacController.GetRoles()))
+	getRoutes.Handle("/roles/{id:[0-9]+}", middleware.IsAdminMiddleware(rbacController.GetRole()))
+	getRoutes.Handle("/permissions", middleware.IsAdminMiddleware(rbacController.GetPermissions()))
+	getRoutes.Handle("/permissions/{id:[0-9]+}", middleware.IsAdminMiddleware(rbacController.GetPermission()))
+	getRoutes.Handle("/users/{id:[0-9]+}/roles", middleware.IsAdminMiddleware(rbacController.GetUserRoles()))
+
+	putRoutes := router.Methods(http.MethodPut).Subrouter()
+	putRoutes.Handle("/roles/{id:[0-9]+}", middleware.IsAdminMiddleware(rbacController.UpdateRole()))
+	putRoutes.Handle("/permissions/{id:[0-9]+}", middleware.IsAdminMiddleware(rbacController.UpdatePermission()))
+	putRoutes.Handle("/users/{id:[0-9]+}/roles/{roleId:[0-9]+}", middleware.IsAdminMiddleware(rbacController.AssignRole()))
+
+	deleteRoutes := router.Methods(http.MethodDelete).Subrouter()
+	deleteRoutes.Handle("/roles/{id:[0-9]+}", middleware.IsAdminMiddleware(rbacController.DeleteRole()))
+	deleteRoutes.Handle("/permissions/{id:[0-9]+}", middleware.IsAdminMiddleware(rbacController.DeletePermission()))
+	deleteRoutes.Handle("/users/{id:[0-9]+}/roles/{roleId:[0-9]+}", middleware.IsAdminMiddleware(rbacController.UnassignRole()))
+
+	return router
+}
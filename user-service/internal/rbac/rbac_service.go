@@ -0,0 +1,35 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"user-service/internal/model"
+)
+
+var (
+	ErrRoleNotFound            error = errors.New("роль не найдена")
+	ErrRoleAlreadyExists       error = errors.New("роль с заданным именем уже существует")
+	ErrPermissionNotFound      error = errors.New("право не найдено")
+	ErrPermissionAlreadyExists error = errors.New("право с заданным именем уже существует")
+	ErrUserNotFound            error = errors.New("пользователь не найден")
+	ErrRoleNotAssigned         error = errors.New("роль не назначена пользователю")
+)
+
+type RBACService interface {
+	CreateRole(ctx context.Context, roleModel *model.RoleModel) (*model.RoleModel, error)
+	GetRoles(ctx context.Context) ([]*model.RoleModel, error)
+	GetRole(ctx context.Context, id int) (*model.RoleModel, error)
+	UpdateRole(ctx context.Context, id int, roleModel *model.RoleModel) (*model.RoleModel, error)
+	DeleteRole(ctx context.Context, id int) error
+
+	CreatePermission(ctx context.Context, permissionModel *model.PermissionModel) (*model.PermissionModel, error)
+	GetPermissions(ctx context.Context) ([]*model.PermissionModel, error)
+	GetPermission(ctx context.Context, id int) (*model.PermissionModel, error)
+	UpdatePermission(ctx context.Context, id int, permissionModel *model.PermissionModel) (*model.PermissionModel, error)
+	DeletePermission(ctx context.Context, id int) error
+
+	// GetUserRoles возвращает роли пользователя и права, полученные через них
+	GetUserRoles(ctx context.Context, userId int) (roles []string, permissions []string, err error)
+	AssignRole(ctx context.Context, userId, roleId int) error
+	UnassignRole(ctx context.Context, userId, roleId int) error
+}
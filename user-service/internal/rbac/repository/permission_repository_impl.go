@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"user-service/internal/model"
+	"user-service/internal/rbac"
+	"user-service/pkg/db"
+)
+
+type permissionRepositoryImpl struct {
+	db db.DB
+}
+
+// Create implements rbac.PermissionRepository.
+func (permissionRepository *permissionRepositoryImpl) Create(ctx context.Context, permissionModel *model.PermissionModel) (*model.PermissionModel, error) {
+	ctx, cancel := permissionRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := permissionRepository.db.Querier(ctx)
+
+	var id int
+	err := dbConnect.QueryRowContext(ctx,
+		"INSERT INTO permissions (name, description) VALUES ($1, $2) RETURNING id",
+		permissionModel.Name,
+		permissionModel.Description,
+	).Scan(&id)
+	if err != nil {
+		if _, ok := db.IsUniqueViolation(err); ok {
+			return nil, rbac.ErrPermissionAlreadyExists
+		}
+		return nil, err
+	}
+
+	return permissionRepository.getOneById(ctx, id)
+}
+
+// GetAll implements rbac.PermissionRepository.
+func (permissionRepository *permissionRepositoryImpl) GetAll(ctx context.Context) ([]*model.PermissionModel, error) {
+	ctx, cancel := permissionRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := permissionRepository.db.Querier(ctx)
+
+	rows, err := dbConnect.QueryContext(ctx, "SELECT id, name, description FROM permissions ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var permissions []*model.PermissionModel
+	for rows.Next() {
+		var foundPermission model.PermissionModel
+		if err := rows.Scan(&foundPermission.Id, &foundPermission.Name, &foundPermission.Description); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, &foundPermission)
+	}
+
+	return permissions, rows.Err()
+}
+
+// GetOne implements rbac.PermissionRepository.
+func (permissionRepository *permissionRepositoryImpl) GetOne(ctx context.Context, id int) (*model.PermissionModel, error) {
+	return permissionRepository.getOneById(ctx, id)
+}
+
+// Update implements rbac.PermissionRepository.
+func (permissionRepository *permissionRepositoryImpl) Update(ctx context.Context, id int, permissionModel *model.PermissionModel) (*model.PermissionModel, error) {
+	ctx, cancel := permissionRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := permissionRepository.db.Querier(ctx)
+
+	result, err := dbConnect.ExecContext(ctx,
+		"UPDATE permissions SET name=$1, description=$2 WHERE id=$3",
+		permissionModel.Name,
+		permissionModel.Description,
+		id,
+	)
+	if err != nil {
+		if _, ok := db.IsUniqueViolation(err); ok {
+			return nil, rbac.ErrPermissionAlreadyExists
+		}
+		return nil, err
+	}
+	if count, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if count == 0 {
+		return nil, rbac.ErrPermissionNotFound
+	}
+
+	return permissionRepository.getOneById(ctx, id)
+}
+
+// Delete implements rbac.PermissionRepository.
+func (permissionRepository *permissionRepositoryImpl) Delete(ctx context.Context, id int) error {
+	ctx, cancel := permissionRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := permissionRepository.db.Querier(ctx)
+
+	result, err := dbConnect.ExecContext(ctx, "DELETE FROM permissions WHERE id=$1", id)
+	if err != nil {
+		return err
+	}
+
+	if count, err := result.RowsAffected(); err != nil {
+		return err
+	} else if count == 0 {
+		return rbac.ErrPermissionNotFound
+	}
+
+	return nil
+}
+
+func (permissionRepository *permissionRepositoryImpl) getOneById(ctx context.Context, id int) (*model.PermissionModel, error) {
+	ctx, cancel := permissionRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := permissionRepository.db.Querier(ctx)
+
+	var foundPermission model.PermissionModel
+	err := dbConnect.QueryRowContext(ctx, "SELECT id, name, description FROM permissions WHERE id=$1", id).Scan(
+		&foundPermission.Id,
+		&foundPermission.Name,
+		&foundPermission.Description,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, rbac.ErrPermissionNotFound
+		}
+		return nil, err
+	}
+	return &foundPermission, nil
+}
+
+func NewPermissionRepository(db db.DB) rbac.PermissionRepository {
+	return &permissionRepositoryImpl{
+		db: db,
+	}
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"user-service/internal/model"
+	"user-service/internal/rbac"
+	"user-service/pkg/db"
+
+	"github.com/lib/pq"
+)
+
+// selectRoles выбирает роли вместе с именами их прав, отсортированными по алфавиту
+const selectRoles string = `SELECT r.id, r.name, r.description,
+	COALESCE(array_agg(p.name ORDER BY p.name) FILTER (WHERE p.name IS NOT NULL), '{}')
+	FROM roles r
+	LEFT JOIN role_permissions rp ON rp.role_id = r.id
+	LEFT JOIN permissions p ON p.id = rp.permission_id`
+
+type roleRepositoryImpl struct {
+	db db.DB
+}
+
+// Create implements rbac.RoleRepository.
+func (roleRepository *roleRepositoryImpl) Create(ctx context.Context, roleModel *model.RoleModel) (*model.RoleModel, error) {
+	ctx, cancel := roleRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	var id int
+	err := roleRepository.db.Do(ctx, func(ctx context.Context) error {
+		dbConnect := roleRepository.db.Querier(ctx)
+
+		err := dbConnect.QueryRowContext(ctx,
+			"INSERT INTO roles (name, description) VALUES ($1, $2) RETURNING id",
+			roleModel.Name,
+			roleModel.Description,
+		).Scan(&id)
+		if err != nil {
+			if _, ok := db.IsUniqueViolation(err); ok {
+				return rbac.ErrRoleAlreadyExists
+			}
+			return err
+		}
+
+		return setRolePermissions(ctx, dbConnect, id, roleModel.Permissions)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return roleRepository.getOneById(ctx, id)
+}
+
+// GetAll implements rbac.RoleRepository.
+func (roleRepository *roleRepositoryImpl) GetAll(ctx context.Context) ([]*model.RoleModel, error) {
+	ctx, cancel := roleRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := roleRepository.db.Querier(ctx)
+
+	rows, err := dbConnect.QueryContext(ctx, selectRoles+" GROUP BY r.id ORDER BY r.id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []*model.RoleModel
+	for rows.Next() {
+		var foundRole model.RoleModel
+		if err := rows.Scan(&foundRole.Id, &foundRole.Name, &foundRole.Description, pq.Array(&foundRole.Permissions)); err != nil {
+			return nil, err
+		}
+		roles = append(roles, &foundRole)
+	}
+
+	return roles, rows.Err()
+}
+
+// GetOne implements rbac.RoleRepository.
+func (roleRepository *roleRepositoryImpl) GetOne(ctx context.Context, id int) (*model.RoleModel, error) {
+	return roleRepository.getOneById(ctx, id)
+}
+
+// Update implements rbac.RoleRepository.
+func (roleRepository *roleRepositoryImpl) Update(ctx context.Context, id int, roleModel *model.RoleModel) (*model.RoleModel, error) {
+	ctx, cancel := roleRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	err := roleRepository.db.Do(ctx, func(ctx context.Context) error {
+		dbConnect := roleRepository.db.Querier(ctx)
+
+		result, err := dbConnect.ExecContext(ctx,
+			"UPDATE roles SET name=$1, description=$2 WHERE id=$3",
+			roleModel.Name,
+			roleModel.Description,
+			id,
+		)
+		if err != nil {
+			if _, ok := db.IsUniqueViolation(err); ok {
+				return rbac.ErrRoleAlreadyExists
+			}
+			return err
+		}
+		if count, err := result.RowsAffected(); err != nil {
+			return err
+		} else if count == 0 {
+			return rbac.ErrRoleNotFound
+		}
+
+		return setRolePermissions(ctx, dbConnect, id, roleModel.Permissions)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return roleRepository.getOneById(ctx, id)
+}
+
+// Delete implements rbac.RoleRepository.
+func (roleRepository *roleRepositoryImpl) Delete(ctx context.Context, id int) error {
+	ctx, cancel := roleRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := roleRepository.db.Querier(ctx)
+
+	result, err := dbConnect.ExecContext(ctx, "DELETE FROM roles WHERE id=$1", id)
+	if err != nil {
+		return err
+	}
+
+	if count, err := result.RowsAffected(); err != nil {
+		return err
+	} else if count == 0 {
+		return rbac.ErrRoleNotFound
+	}
+
+	return nil
+}
+
+func (roleRepository *roleRepositoryImpl) getOneById(ctx context.Context, id int) (*model.RoleModel, error) {
+	ctx, cancel := roleRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := roleRepository.db.Querier(ctx)
+
+	var foundRole model.RoleModel
+	err := dbConnect.QueryRowContext(ctx, selectRoles+" WHERE r.id=$1 GROUP BY r.id", id).Scan(
+		&foundRole.Id,
+		&foundRole.Name,
+		&foundRole.Description,
+		pq.Array(&foundRole.Permissions),
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, rbac.ErrRoleNotFound
+		}
+		return nil, err
+	}
+	return &foundRole, nil
+}
+
+// setRolePermissions заменяет набор прав роли в транзакции, начатой вызывающим. Если какое-либо из прав
+// не существует, возвращается rbac.ErrPermissionNotFound. Повторяющиеся имена прав учитываются один раз.
+func setRolePermissions(ctx context.Context, dbConnect db.Querier, roleId int, permissions []string) error {
+	// Каждое существующее право вставляется один раз, поэтому число строк сравнивается с числом различных имен
+	permissions = slices.Clone(permissions)
+	slices.Sort(permissions)
+	permissions = slices.Compact(permissions)
+
+	if _, err := dbConnect.ExecContext(ctx, "DELETE FROM role_permissions WHERE role_id=$1", roleId); err != nil {
+		return err
+	}
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	result, err := dbConnect.ExecContext(ctx,
+		"INSERT INTO role_permissions (role_id, permission_id) SELECT $1, id FROM permissions WHERE name = ANY($2)",
+		roleId,
+		pq.Array(permissions),
+	)
+	if err != nil {
+		return err
+	}
+	if count, err := result.RowsAffected(); err != nil {
+		return err
+	} else if count != int64(len(permissions)) {
+		return rbac.ErrPermissionNotFound
+	}
+	return nil
+}
+
+func NewRoleRepository(db db.DB) rbac.RoleRepository {
+	return &roleRepositoryImpl{
+		db: db,
+	}
+}
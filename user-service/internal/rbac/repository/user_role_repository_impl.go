@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"user-service/internal/rbac"
+	"user-service/pkg/db"
+)
+
+// userRolesUserIdConstraint — имя ограничения внешнего ключа user_roles.user_id,
+// по которому ошибка назначения роли отличается от отсутствия самой роли
+const userRolesUserIdConstraint string = "user_roles_user_id_fkey"
+
+type userRoleRepositoryImpl struct {
+	db db.DB
+}
+
+// GetRoles implements rbac.UserRoleRepository.
+func (userRoleRepository *userRoleRepositoryImpl) GetRoles(ctx context.Context, userId int) ([]string, error) {
+	return userRoleRepository.queryNames(ctx,
+		`SELECT r.name FROM roles r
+		JOIN user_roles ur ON ur.role_id = r.id
+		WHERE ur.user_id=$1
+		ORDER BY r.name`,
+		userId,
+	)
+}
+
+// GetPermissions implements rbac.UserRoleRepository.
+func (userRoleRepository *userRoleRepositoryImpl) GetPermissions(ctx context.Context, userId int) ([]string, error) {
+	return userRoleRepository.queryNames(ctx,
+		`SELECT DISTINCT p.name FROM permissions p
+		JOIN role_permissions rp ON rp.permission_id = p.id
+		JOIN user_roles ur ON ur.role_id = rp.role_id
+		WHERE ur.user_id=$1
+		ORDER BY p.name`,
+		userId,
+	)
+}
+
+// Assign implements rbac.UserRoleRepository.
+func (userRoleRepository *userRoleRepositoryImpl) Assign(ctx context.Context, userId, roleId int) error {
+	ctx, cancel := userRoleRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := userRoleRepository.db.Querier(ctx)
+
+	_, err := dbConnect.ExecContext(ctx,
+		"INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		userId,
+		roleId,
+	)
+	if constraint, ok := db.IsForeignKeyViolation(err); ok {
+		if constraint == userRolesUserIdConstraint {
+			return rbac.ErrUserNotFound
+		}
+		return rbac.ErrRoleNotFound
+	}
+	return err
+}
+
+// Unassign implements rbac.UserRoleRepository.
+func (userRoleRepository *userRoleRepositoryImpl) Unassign(ctx context.Context, userId, roleId int) error {
+	ctx, cancel := userRoleRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := userRoleRepository.db.Querier(ctx)
+
+	result, err := dbConnect.ExecContext(ctx, "DELETE FROM user_roles WHERE user_id=$1 AND role_id=$2", userId, roleId)
+	if err != nil {
+		return err
+	}
+
+	if count, err := result.RowsAffected(); err != nil {
+		return err
+	} else if count == 0 {
+		return rbac.ErrRoleNotAssigned
+	}
+
+	return nil
+}
+
+// queryNames выполняет запрос, возвращающий один текстовый столбец
+func (userRoleRepository *userRoleRepositoryImpl) queryNames(ctx context.Context, query string, args ...any) ([]string, error) {
+	ctx, cancel := userRoleRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := userRoleRepository.db.Querier(ctx)
+
+	rows, err := dbConnect.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+func NewUserRoleRepository(db db.DB) rbac.UserRoleRepository {
+	return &userRoleRepositoryImpl{
+		db: db,
+	}
+}
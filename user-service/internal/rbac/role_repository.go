@@ -0,0 +1,14 @@
+package rbac
+
+import (
+	"context"
+	"user-service/internal/model"
+)
+
+type RoleRepository interface {
+	Create(ctx context.Context, roleModel *model.RoleModel) (*model.RoleModel, error)
+	GetAll(ctx context.Context) ([]*model.RoleModel, error)
+	GetOne(ctx context.Context, id int) (*model.RoleModel, error)
+	Update(ctx context.Context, id int, roleModel *model.RoleModel) (*model.RoleModel, error)
+	Delete(ctx context.Context, id int) error
+}
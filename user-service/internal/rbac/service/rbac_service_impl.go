@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"user-service/internal/model"
+	"user-service/internal/rbac"
+	"user-service/internal/user"
+)
+
+type rbacServiceImpl struct {
+	roleRepository       rbac.RoleRepository
+	permissionRepository rbac.PermissionRepository
+	userRoleRepository   rbac.UserRoleRepository
+	userRepository       user.UserRepository
+}
+
+// CreateRole implements rbac.RBACService.
+func (rbacService *rbacServiceImpl) CreateRole(ctx context.Context, roleModel *model.RoleModel) (*model.RoleModel, error) {
+	return rbacService.roleRepository.Create(ctx, roleModel)
+}
+
+// GetRoles implements rbac.RBACService.
+func (rbacService *rbacServiceImpl) GetRoles(ctx context.Context) ([]*model.RoleModel, error) {
+	return rbacService.roleRepository.GetAll(ctx)
+}
+
+// GetRole implements rbac.RBACService.
+func (rbacService *rbacServiceImpl) GetRole(ctx context.Context, id int) (*model.RoleModel, error) {
+	return rbacService.roleRepository.GetOne(ctx, id)
+}
+
+// UpdateRole implements rbac.RBACService.
+func (rbacService *rbacServiceImpl) UpdateRole(ctx context.Context, id int, roleModel *model.RoleModel) (*model.RoleModel, error) {
+	return rbacService.roleRepository.Update(ctx, id, roleModel)
+}
+
+// DeleteRole implements rbac.RBACService.
+func (rbacService *rbacServiceImpl) DeleteRole(ctx context.Context, id int) error {
+	return rbacService.roleRepository.Delete(ctx, id)
+}
+
+// CreatePermission implements rbac.RBACService.
+func (rbacService *rbacServiceImpl) CreatePermission(ctx context.Context, permissionModel *model.PermissionModel) (*model.PermissionModel, error) {
+	return rbacService.permissionRepository.Create(ctx, permissionModel)
+}
+
+// GetPermissions implements rbac.RBACService.
+func (rbacService *rbacServiceImpl) GetPermissions(ctx context.Context) ([]*model.PermissionModel, error) {
+	return rbacService.permissionRepository.GetAll(ctx)
+}
+
+// GetPermission implements rbac.RBACService.
+func (rbacService *rbacServiceImpl) GetPermission(ctx context.Context, id int) (*model.PermissionModel, error) {
+	return rbacService.permissionRepository.GetOne(ctx, id)
+}
+
+// UpdatePermission implements rbac.RBACService.
+func (rbacService *rbacServiceImpl) UpdatePermission(ctx context.Context, id int, permissionModel *model.PermissionModel) (*model.PermissionModel, error) {
+	return rbacService.permissionRepository.Update(ctx, id, permissionModel)
+}
+
+// DeletePermission implements rbac.RBACService.
+func (rbacService *rbacServiceImpl) DeletePermission(ctx context.Context, id int) error {
+	return rbacService.permissionRepository.Delete(ctx, id)
+}
+
+// GetUserRoles implements rbac.RBACService.
+func (rbacService *rbacServiceImpl) GetUserRoles(ctx context.Context, userId int) ([]string, []string, error) {
+	if isExist, err := rbacService.userRepository.ExistById(ctx, userId); err != nil {
+		return nil, nil, err
+	} else if !isExist {
+		return nil, nil, rbac.ErrUserNotFound
+	}
+
+	roles, err := rbacService.userRoleRepository.GetRoles(ctx, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+	permissions, err := rbacService.userRoleRepository.GetPermissions(ctx, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return roles, permissions, nil
+}
+
+// AssignRole implements rbac.RBACService.
+func (rbacService *rbacServiceImpl) AssignRole(ctx context.Context, userId, roleId int) error {
+	return rbacService.userRoleRepository.Assign(ctx, userId, roleId)
+}
+
+// UnassignRole implements rbac.RBACService.
+func (rbacService *rbacServiceImpl) UnassignRole(ctx context.Context, userId, roleId int) error {
+	return rbacService.userRoleRepository.Unassign(ctx, userId, roleId)
+}
+
+func NewRBACService(
+	roleRepository rbac.RoleRepository,
+	permissionRepository rbac.PermissionRepository,
+	userRoleRepository rbac.UserRoleRepository,
+	userRepository user.UserRepository,
+) rbac.RBACService {
+	return &rbacServiceImpl{
+		roleRepository:       roleRepository,
+		permissionRepository: permissionRepository,
+		userRoleRepository:   userRoleRepository,
+		userRepository:       userRepository,
+	}
+}
@@ -0,0 +1,13 @@
+package rbac
+
+import "context"
+
+// UserRoleRepository хранит назначение ролей пользователям
+type UserRoleRepository interface {
+	// GetRoles возвращает имена ролей пользователя
+	GetRoles(ctx context.Context, userId int) ([]string, error)
+	// GetPermissions возвращает права, полученные пользователем через все его роли
+	GetPermissions(ctx context.Context, userId int) ([]string, error)
+	Assign(ctx context.Context, userId, roleId int) error
+	Unassign(ctx context.Context, userId, roleId int) error
+}
@@ -1,20 +1,61 @@
 package http_controller
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"io"
+	"errors"
 	"log"
+	"mime"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 	"user-service/internal/dto"
+	"user-service/internal/http_response"
 	"user-service/internal/mapper"
+	"user-service/internal/messages"
 	"user-service/internal/user"
 	"user-service/pkg/http_helper"
-
-	"github.com/gorilla/mux"
+	"user-service/pkg/patch"
+	"user-service/pkg/problem"
 )
 
 const defaultPageLimit int = 10
 
+// Заголовки ответа со списком пользователей, в которых передаются курсоры соседних страниц
+const (
+	nextCursorHeader string = "X-Next-Cursor"
+	prevCursorHeader string = "X-Prev-Cursor"
+)
+
+// Типы ошибок RFC 7807, которые возвращает контроллер пользователей
+const (
+	problemTypeValidation      string = "/problems/validation-error"
+	problemTypeUserNotFound    string = "/problems/user-not-found"
+	problemTypeUsernameTaken   string = "/problems/username-taken"
+	problemTypeForbidden       string = "/problems/forbidden"
+	problemTypeVersionMismatch string = "/problems/version-mismatch"
+)
+
+// patchFunc применяет документ изменений к документу пользователя
+type patchFunc func(target []byte, patch []byte) ([]byte, error)
+
+// patchFuncs сопоставляет поддерживаемые типы содержимого PATCH со способами применения изменений
+var patchFuncs = map[string]patchFunc{
+	patch.MergePatchContentType: patch.Merge,
+	patch.JSONPatchContentType:  patch.Apply,
+}
+
+// cacheControl разрешает хранить ответы на чтение, но требует перепроверять копию перед каждым
+// использованием: кэш передает If-None-Match, и сервер отвечает 304 без тела. Ответы зависят
+// от прав владельца токена, поэтому без перепроверки, проходящей через авторизацию, копию использовать нельзя.
+const cacheControl string = "no-cache"
+
+// acceptPatch перечисляет поддерживаемые типы содержимого PATCH для заголовка Accept-Patch
+const acceptPatch string = patch.MergePatchContentType + ", " + patch.JSONPatchContentType
+
 type userControllerImpl struct {
 	userService user.UserService
 	userMapper  mapper.UserMapper
@@ -24,115 +65,28 @@ type userControllerImpl struct {
 func (userController *userControllerImpl) Create() http.Handler {
 	return http.HandlerFunc(
 		func(responseWriter http.ResponseWriter, request *http.Request) {
-			log.Println("UserController.Create:", request.URL.Path, "from", request.Host)
+			const handlerName string = "UserController.Create:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
 
-			data, err := io.ReadAll(request.Body)
-			if err != nil {
-				errText := "Ошибка чтения тела запроса!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.Create:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
+			var dtoRequest dto.UserRequest
+			if !http_response.ReadRequest(responseWriter, request, handlerName, &dtoRequest, "dto.UserRequest") {
 				return
 			}
 
-			if len(data) == 0 {
-				errText := "Получено пустое тело запроса!"
-				http.Error(responseWriter, errText, http.StatusBadRequest)
-				log.Println(
-					"UserController.Create:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
+			userModel, err := userController.userMapper.ToModel(dtoRequest)
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.UserConvertFailed, err)
 				return
 			}
 
-			var dtoRequest dto.UserRequest
-			if json.Unmarshal(data, &dtoRequest) != nil {
-				errText := "Ошибка конвертации JSON в dto.UserRequest!"
-				http.Error(responseWriter, errText, http.StatusBadRequest)
-				log.Println(
-					"UserController.Create:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
+			userModel, err = userController.userService.Create(request.Context(), userModel)
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.UserCreateFailed, err)
 				return
 			}
 
-			userModel, err := userController.userMapper.ToModel(dtoRequest)
-			if err != nil {
-				errText := "Ошибка конвертации dto.UserRequest в model.UserModel!"
-				http.Error(responseWriter, errText, http.StatusBadRequest)
-				log.Println(
-					"UserController.Create:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
-				return
-			}
-
-			if isExistUsername, err := userController.userService.ExistByUsername(userModel.Username); err != nil {
-				errText := "Ошибка проверки существования пользователя с заданным именем!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.Create:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
-				return
-			} else if isExistUsername {
-				errText := "Пользователь с заданным именем уже существует!"
-				http.Error(responseWriter, errText, http.StatusConflict)
-				log.Println(
-					"UserController.Create:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
-				return
-			}
-
-			userModel, err = userController.userService.Create(userModel)
-			if err != nil {
-				errText := "Ошибка создания записи в базе данных!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.Create:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
-				return
-			}
-
-			dtoResponse := userController.userMapper.ToDto(*userModel)
-			responseWriter.WriteHeader(http.StatusCreated)
-
-			if err := json.NewEncoder(responseWriter).Encode(dtoResponse); err != nil {
-				errText := "Ошибка конвертации dto.UserResponse в JSON!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.Create:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
-				return
-			}
-
-			log.Println(
-				"UserController.Create:", request.URL.Path,
-				"from", request.Host,
-				"result:",
-				dtoResponse,
-			)
+			responseWriter.Header().Set("ETag", versionETag(userModel.Version))
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusCreated, userController.userMapper.ToDto(*userModel))
 		},
 	)
 }
@@ -141,86 +95,77 @@ func (userController *userControllerImpl) Create() http.Handler {
 func (userController *userControllerImpl) GetAll() http.Handler {
 	return http.HandlerFunc(
 		func(responseWriter http.ResponseWriter, request *http.Request) {
-			log.Println("UserController.GetAll Handler Serving:", request.URL.Path, "from", request.Host)
+			const handlerName string = "UserController.GetAll:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
 
-			queryParams := request.URL.Query()
+			pageNumber, ok := readQueryParam(responseWriter, request, handlerName, "page", 0)
+			if !ok {
+				return
+			}
 
-			pageNubmer, err := http_helper.GetQueryParam[int](queryParams, "page")
-			if err != nil {
-				switch err {
-				case http_helper.ErrParamIsEmpty:
-					pageNubmer = 0
-				default:
-					errText := "Ошибка конвертации параметра запроса id!"
-					http.Error(responseWriter, errText, http.StatusInternalServerError)
-					log.Println(
-						"UserController.GetAll:", request.URL.Path,
-						"from", request.Host,
-						errText,
-						err,
-					)
-					return
-				}
+			pageLimit, ok := readQueryParam(responseWriter, request, handlerName, "limit", defaultPageLimit)
+			if !ok {
+				return
 			}
-			if pageNubmer > 0 {
-				pageNubmer--
+
+			withEnvelope, ok := readQueryParam(responseWriter, request, handlerName, "envelope", false)
+			if !ok {
+				return
 			}
 
-			pageLimit, err := http_helper.GetQueryParam[int](queryParams, "limit")
-			if err != nil {
-				switch err {
-				case http_helper.ErrParamIsEmpty:
-					pageLimit = defaultPageLimit
-				default:
-					errText := "Ошибка конвертации параметра запроса limit!"
-					http.Error(responseWriter, errText, http.StatusInternalServerError)
-					log.Println(
-						"UserController.GetAll:", request.URL.Path,
-						"from", request.Host,
-						errText,
-						err,
-					)
-					return
-				}
+			createdAfter, ok := readQueryParam(responseWriter, request, handlerName, "created_after", time.Time{})
+			if !ok {
+				return
 			}
 
-			models, err := userController.userService.GetAll(pageNubmer, pageLimit)
+			query := request.URL.Query()
+			pageCursor := query.Get("cursor")
+			userPage, err := userController.userService.GetAll(request.Context(), user.PageRequest{
+				Filter: user.UserFilter{
+					UsernamePrefix: query.Get("username_prefix"),
+					Search:         query.Get("q"),
+					CreatedAfter:   createdAfter,
+				},
+				Sort:      query.Get("sort"),
+				Cursor:    pageCursor,
+				Page:      pageNumber,
+				Limit:     pageLimit,
+				WithTotal: withEnvelope,
+			})
 			if err != nil {
-				errText := "Ошибка получения списка пользователей!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.GetAll:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
+				writeServiceError(responseWriter, request, handlerName, messages.UserGetAllFailed, err)
 				return
 			}
 
-			var dtoResponses []dto.UserResponse = make([]dto.UserResponse, 0)
-			for _, value := range models {
-				dtoResponses = append(dtoResponses, *userController.userMapper.ToDto(*value))
+			writePageLinks(responseWriter, request, userPage)
+			if userPage.Next != "" {
+				responseWriter.Header().Set(nextCursorHeader, userPage.Next)
+			}
+			if userPage.Prev != "" {
+				responseWriter.Header().Set(prevCursorHeader, userPage.Prev)
 			}
 
-			responseWriter.WriteHeader(http.StatusOK)
-			if err := json.NewEncoder(responseWriter).Encode(dtoResponses); err != nil {
-				errText := "Ошибка конвертации []response.UserResponse в JSON!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.GetAll:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
+			// Конверт отправляется по запросу: без него тело остается массивом для совместимости
+			var dtoResponse any = userController.userMapper.ToDtoList(userPage.Users)
+			if withEnvelope {
+				if pageNumber == 0 && pageCursor == "" {
+					pageNumber = 1
+				}
+				dtoResponse = userController.userMapper.ToPageDto(*userPage, pageNumber, pageLimit)
+			}
+
+			// Last-Modified для списка не отправляется: удаление пользователя не меняет
+			// время изменения оставшихся, и If-Modified-Since вернул бы устаревший список
+			etag, err := contentETag(dtoResponse)
+			if err != nil {
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusInternalServerError, err, messages.UserGetAllFailed)
+				return
+			}
+			if writeNotModified(responseWriter, request, handlerName, etag, time.Time{}) {
 				return
 			}
 
-			log.Println(
-				"UserController.GetAll:", request.URL.Path,
-				"from", request.Host,
-				"result:",
-				dtoResponses,
-			)
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusOK, dtoResponse)
 		},
 	)
 }
@@ -229,90 +174,24 @@ func (userController *userControllerImpl) GetAll() http.Handler {
 func (userController *userControllerImpl) GetOne() http.Handler {
 	return http.HandlerFunc(
 		func(responseWriter http.ResponseWriter, request *http.Request) {
-			log.Println("UserController.GetOne Handler Serving:", request.URL.Path, "from", request.Host)
+			const handlerName string = "UserController.GetOne:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
 
-			params := mux.Vars(request)
-			id, err := http_helper.GetRouteParam[int](params, "id")
-			if err != nil {
-				switch err {
-				case http_helper.ErrParamIsEmpty:
-					errText := "Ошибка заполнения параметра запроса id!"
-					http.Error(responseWriter, errText, http.StatusBadRequest)
-					log.Println(
-						"UserController.GetOne:", request.URL.Path,
-						"from", request.Host,
-						errText,
-						err,
-					)
-					return
-				default:
-					errText := "Ошибка конвертации параметра запроса id!"
-					http.Error(responseWriter, errText, http.StatusInternalServerError)
-					log.Println(
-						"UserController.GetOne:", request.URL.Path,
-						"from", request.Host,
-						errText,
-						err,
-					)
-					return
-				}
+			id, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "id")
+			if !ok {
+				return
 			}
 
-			if isExist, err := userController.userService.ExistById(id); err != nil {
-				errText := "Ошибка проверки существования пользователя с заданным id!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.GetOne:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
-				return
-			} else if !isExist {
-				errText := "Пользователь с заданным id не существует!"
-				http.Error(responseWriter, errText, http.StatusNotFound)
-				log.Println(
-					"UserController.GetOne:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
+			userModel, err := userController.userService.GetOne(request.Context(), id)
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.UserGetOneFailed, err)
 				return
 			}
 
-			userModel, err := userController.userService.GetOne(id)
-			if err != nil {
-				errText := "Ошибка получения пользователя!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.GetOne:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
-				return
-			}
-
-			dtoResponse := userController.userMapper.ToDto(*userModel)
-			responseWriter.WriteHeader(http.StatusOK)
-			if err := json.NewEncoder(responseWriter).Encode(dtoResponse); err != nil {
-				errText := "Ошибка конвертации response.UserResponse в JSON!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.GetOne:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
-				return
-			}
-
-			log.Println(
-				"UserController.GetOne:", request.URL.Path,
-				"from", request.Host,
-				"result:",
-				dtoResponse,
-			)
+			if writeNotModified(responseWriter, request, handlerName, versionETag(userModel.Version), userModel.UpdatedAt) {
+				return
+			}
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusOK, userController.userMapper.ToDto(*userModel))
 		},
 	)
 }
@@ -321,163 +200,108 @@ func (userController *userControllerImpl) GetOne() http.Handler {
 func (userController *userControllerImpl) Update() http.Handler {
 	return http.HandlerFunc(
 		func(responseWriter http.ResponseWriter, request *http.Request) {
-			log.Println("UserController.Update Handler Serving:", request.URL.Path, "from", request.Host)
+			const handlerName string = "UserController.Update:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
 
-			params := mux.Vars(request)
-			id, err := http_helper.GetRouteParam[int](params, "id")
+			id, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "id")
+			if !ok {
+				return
+			}
+
+			var dtoRequest dto.UserRequest
+			if !http_response.ReadRequest(responseWriter, request, handlerName, &dtoRequest, "dto.UserRequest") {
+				return
+			}
+
+			userModel, err := userController.userMapper.ToModel(dtoRequest)
 			if err != nil {
-				switch err {
-				case http_helper.ErrParamIsEmpty:
-					errText := "Ошибка заполнения параметра запроса id!"
-					http.Error(responseWriter, errText, http.StatusBadRequest)
-					log.Println(
-						"UserController.Update:", request.URL.Path,
-						"from", request.Host,
-						errText,
-						err,
-					)
-					return
-				default:
-					errText := "Ошибка конвертации параметра запроса id!"
-					http.Error(responseWriter, errText, http.StatusInternalServerError)
-					log.Println(
-						"UserController.Update:", request.URL.Path,
-						"from", request.Host,
-						errText,
-						err,
-					)
-					return
-				}
+				writeServiceError(responseWriter, request, handlerName, messages.UserConvertFailed, err)
+				return
+			}
+
+			userModel.Version, ok = userController.checkIfMatch(responseWriter, request, handlerName, messages.UserUpdateFailed, id)
+			if !ok {
+				return
+			}
+
+			userModel, err = userController.userService.Update(request.Context(), id, userModel)
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.UserUpdateFailed, err)
+				return
+			}
+
+			responseWriter.Header().Set("ETag", versionETag(userModel.Version))
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusOK, userController.userMapper.ToDto(*userModel))
+		},
+	)
+}
+
+// Patch implements user.UserController.
+func (userController *userControllerImpl) Patch() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "UserController.Patch:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			id, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "id")
+			if !ok {
+				return
+			}
+
+			applyPatch, ok := readPatchType(responseWriter, request, handlerName)
+			if !ok {
+				return
+			}
+
+			data, ok := http_response.ReadBody(responseWriter, request, handlerName)
+			if !ok {
+				return
 			}
 
-			if isExist, err := userController.userService.ExistById(id); err != nil {
-				errText := "Ошибка проверки существования пользователя с заданным id!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.Update:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
+			userModel, err := userController.userService.GetOne(request.Context(), id)
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.UserPatchFailed, err)
 				return
-			} else if !isExist {
-				errText := "Пользователь с заданным id не существует!"
-				http.Error(responseWriter, errText, http.StatusNotFound)
-				log.Println(
-					"UserController.Update:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
+			}
+			if !http_helper.IfMatch(request.Header.Values("If-Match"), versionETag(userModel.Version)) {
+				writeServiceError(responseWriter, request, handlerName, messages.UserPatchFailed, user.ErrVersionMismatch)
 				return
 			}
 
-			data, err := io.ReadAll(request.Body)
+			document, err := json.Marshal(userController.userMapper.ToPatchRequest(*userModel))
 			if err != nil {
-				errText := "Ошибка чтения тела запроса!"
-				http.Error(responseWriter, errText, http.StatusBadRequest)
-				log.Println(
-					"UserController.Update:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusInternalServerError, err, messages.UserPatchFailed)
 				return
 			}
 
-			if len(data) == 0 {
-				errText := "Получено пустое тело запроса!"
-				http.Error(responseWriter, errText, http.StatusBadRequest)
-				log.Println(
-					"UserController.Update:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
+			document, err = applyPatch(document, data)
+			if err != nil {
+				writePatchError(responseWriter, request, handlerName, err)
 				return
 			}
 
-			var dtoRequest dto.UserRequest
-			if json.Unmarshal(data, &dtoRequest) != nil {
-				errText := "Ошибка конвертации JSON в dto.UserRequest!"
-				http.Error(responseWriter, errText, http.StatusBadRequest)
-				log.Println(
-					"UserController.Update:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
+			var patchRequest dto.UserPatchRequest
+			if err := json.Unmarshal(document, &patchRequest); err != nil {
+				http_response.WriteError(responseWriter, request, handlerName, http.StatusBadRequest, err, messages.RequestInvalidJSON, "dto.UserPatchRequest")
 				return
 			}
 
-			userModel, err := userController.userMapper.ToModel(dtoRequest)
+			patchedModel, err := userController.userMapper.PatchToModel(*userModel, patchRequest)
 			if err != nil {
-				errText := "Ошибка конвертации dto.UserRequest в model.UserModel!"
-				http.Error(responseWriter, errText, http.StatusBadRequest)
-				log.Println(
-					"UserController.Update:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
-				return
-			}
-
-			if isExistUsername, err := userController.userService.ExistByUsernameAndNotId(userModel.Username, id); err != nil {
-				errText := "Ошибка проверки существования пользователя с заданным именем!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.Update:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
-				return
-			} else if isExistUsername {
-				errText := "Пользователь с заданным именем уже существует!"
-				http.Error(responseWriter, errText, http.StatusConflict)
-				log.Println(
-					"UserController.Update:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
-				return
-			}
-
-			userModel, err = userController.userService.Update(id, userModel)
+				writeServiceError(responseWriter, request, handlerName, messages.UserConvertFailed, err)
+				return
+			}
+
+			// Изменения применены к прочитанной версии, поэтому запись не должна перезаписать более новую
+			patchedModel.Version = userModel.Version
+			patchedModel, err = userController.userService.Update(request.Context(), id, patchedModel)
 			if err != nil {
-				errText := "Ошибка обновления записи в базе данных!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.Update:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
-				return
-			}
-
-			dtoResponse := userController.userMapper.ToDto(*userModel)
-			responseWriter.WriteHeader(http.StatusOK)
-			if err := json.NewEncoder(responseWriter).Encode(dtoResponse); err != nil {
-				errText := "Ошибка конвертации response.UserResponse в JSON!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.Update:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
-				return
-			}
-
-			log.Println(
-				"UserController.Update:", request.URL.Path,
-				"from", request.Host,
-				"result:",
-				dtoResponse,
-			)
+				writeServiceError(responseWriter, request, handlerName, messages.UserPatchFailed, err)
+				return
+			}
+
+			responseWriter.Header().Set("ETag", versionETag(patchedModel.Version))
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusOK, userController.userMapper.ToDto(*patchedModel))
 		},
 	)
 }
@@ -486,66 +310,21 @@ func (userController *userControllerImpl) Update() http.Handler {
 func (userController *userControllerImpl) Delete() http.Handler {
 	return http.HandlerFunc(
 		func(responseWriter http.ResponseWriter, request *http.Request) {
-			log.Println("UserController.Delete Handler Serving:", request.URL.Path, "from", request.Host)
+			const handlerName string = "UserController.Delete:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
 
-			params := mux.Vars(request)
-			id, err := http_helper.GetRouteParam[int](params, "id")
-			if err != nil {
-				switch err {
-				case http_helper.ErrParamIsEmpty:
-					errText := "Ошибка заполнения параметра запроса id!"
-					http.Error(responseWriter, errText, http.StatusBadRequest)
-					log.Println(
-						"UserController.Delete:", request.URL.Path,
-						"from", request.Host,
-						errText,
-						err,
-					)
-					return
-				default:
-					errText := "Ошибка конвертации параметра запроса id!"
-					http.Error(responseWriter, errText, http.StatusInternalServerError)
-					log.Println(
-						"UserController.Delete:", request.URL.Path,
-						"from", request.Host,
-						errText,
-						err,
-					)
-					return
-				}
+			id, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "id")
+			if !ok {
+				return
 			}
 
-			if isExist, err := userController.userService.ExistById(id); err != nil {
-				errText := "Ошибка проверки существования пользователя с заданным id!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.Delete:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
-				return
-			} else if !isExist {
-				errText := "Пользователь с заданным id не существует!"
-				http.Error(responseWriter, errText, http.StatusNotFound)
-				log.Println(
-					"UserController.Delete:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
+			version, ok := userController.checkIfMatch(responseWriter, request, handlerName, messages.UserDeleteFailed, id)
+			if !ok {
 				return
 			}
 
-			if err := userController.userService.Delete(id); err != nil {
-				errText := "Ошибка удаления пользователя!"
-				http.Error(responseWriter, errText, http.StatusInternalServerError)
-				log.Println(
-					"UserController.Delete:", request.URL.Path,
-					"from", request.Host,
-					errText,
-					err,
-				)
+			if err := userController.userService.Delete(request.Context(), id, version); err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.UserDeleteFailed, err)
 				return
 			}
 
@@ -555,6 +334,219 @@ func (userController *userControllerImpl) Delete() http.Handler {
 	)
 }
 
+// SetTenant implements user.UserController.
+func (userController *userControllerImpl) SetTenant() http.Handler {
+	return http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			const handlerName string = "UserController.SetTenant:"
+			log.Println(handlerName, request.URL.Path, "from", request.Host)
+
+			id, ok := http_response.ReadRouteParam(responseWriter, request, handlerName, "id")
+			if !ok {
+				return
+			}
+
+			var dtoRequest dto.UserTenantRequest
+			if !http_response.ReadRequest(responseWriter, request, handlerName, &dtoRequest, "dto.UserTenantRequest") {
+				return
+			}
+
+			tenant, err := userController.userMapper.ToTenant(dtoRequest)
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.UserSetTenantFailed, err)
+				return
+			}
+
+			version, ok := userController.checkIfMatch(responseWriter, request, handlerName, messages.UserSetTenantFailed, id)
+			if !ok {
+				return
+			}
+
+			userModel, err := userController.userService.SetTenant(request.Context(), id, tenant, version)
+			if err != nil {
+				writeServiceError(responseWriter, request, handlerName, messages.UserSetTenantFailed, err)
+				return
+			}
+
+			responseWriter.Header().Set("ETag", versionETag(userModel.Version))
+			http_response.WriteResponse(responseWriter, request, handlerName, http.StatusOK, userController.userMapper.ToDto(*userModel))
+		},
+	)
+}
+
+// checkIfMatch проверяет заголовок If-Match по текущей версии пользователя и возвращает версию,
+// которая должна совпасть при записи. Без заголовка возвращается 0 — версия при записи не проверяется.
+// При ошибке ответ уже отправлен и возвращается false.
+func (userController *userControllerImpl) checkIfMatch(responseWriter http.ResponseWriter, request *http.Request, handlerName string, messageKey string, id int) (int, bool) {
+	ifMatch := request.Header.Values("If-Match")
+	if len(ifMatch) == 0 {
+		return 0, true
+	}
+
+	userModel, err := userController.userService.GetOne(request.Context(), id)
+	if err != nil {
+		writeServiceError(responseWriter, request, handlerName, messageKey, err)
+		return 0, false
+	}
+	if !http_helper.IfMatch(ifMatch, versionETag(userModel.Version)) {
+		writeServiceError(responseWriter, request, handlerName, messageKey, user.ErrVersionMismatch)
+		return 0, false
+	}
+	return userModel.Version, true
+}
+
+// writePageLinks отправляет ссылки RFC 8288 на первую, предыдущую, следующую и последнюю страницы
+// списка. Ссылки сохраняют остальные параметры запроса и переходят к страницам по курсорам.
+func writePageLinks(responseWriter http.ResponseWriter, request *http.Request, userPage *user.UserPage) {
+	header := responseWriter.Header()
+	header.Add("Link", http_helper.Link(pageURL(request, ""), "first"))
+	for _, link := range []struct{ cursor, rel string }{
+		{userPage.Prev, "prev"},
+		{userPage.Next, "next"},
+		{userPage.Last, "last"},
+	} {
+		if link.cursor != "" {
+			header.Add("Link", http_helper.Link(pageURL(request, link.cursor), link.rel))
+		}
+	}
+}
+
+// pageURL возвращает ссылку на страницу списка с курсором pageCursor или на первую страницу,
+// если курсор пуст
+func pageURL(request *http.Request, pageCursor string) string {
+	query := request.URL.Query()
+	query.Del("page")
+	query.Del("cursor")
+	if pageCursor != "" {
+		query.Set("cursor", pageCursor)
+	}
+	return (&url.URL{Path: request.URL.Path, RawQuery: query.Encode()}).String()
+}
+
+// writeNotModified отправляет валидаторы кэша etag и lastModified. Если у клиента актуальная копия,
+// отвечает 304 Not Modified и возвращает true, иначе ответ нужно отправить обычным образом.
+func writeNotModified(responseWriter http.ResponseWriter, request *http.Request, handlerName string, etag string, lastModified time.Time) bool {
+	header := responseWriter.Header()
+	header.Set("ETag", etag)
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	header.Set("Cache-Control", cacheControl)
+	header.Add("Vary", "Authorization")
+
+	if !http_helper.NotModified(request, etag, lastModified) {
+		return false
+	}
+	responseWriter.WriteHeader(http.StatusNotModified)
+	log.Println(handlerName, request.URL.Path, "from", request.Host, "not modified")
+	return true
+}
+
+// contentETag возвращает сильный тег сущности по содержимому ответа dtoResponse
+func contentETag(dtoResponse any) (string, error) {
+	data, err := json.Marshal(dtoResponse)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return http_helper.ETag(hex.EncodeToString(sum[:16])), nil
+}
+
+// versionETag возвращает тег сущности для версии пользователя
+func versionETag(version int) string {
+	return http_helper.ETag(strconv.Itoa(version))
+}
+
+// writeServiceError сопоставляет ошибку предметной области с кодом ответа и типом ошибки.
+// Это единственное место, где ошибки пакета user превращаются в HTTP-ответы.
+// Сообщение messageKey описывает неудавшуюся операцию; неизвестные ошибки считаются внутренними.
+func writeServiceError(responseWriter http.ResponseWriter, request *http.Request, handlerName string, messageKey string, err error) {
+	ctx := request.Context()
+	var problemDetails *problem.Problem
+	switch {
+	case errors.Is(err, user.ErrValidation):
+		problemDetails = http_response.NewProblem(ctx, problemTypeValidation, http.StatusBadRequest, messages.UserValidation, messageKey)
+		var validationErr *user.ValidationError
+		if errors.As(err, &validationErr) {
+			details := make([]string, 0, len(validationErr.Fields))
+			for _, field := range validationErr.Fields {
+				message := messages.Field(ctx, field.Field, field.Rule, field.Param)
+				problemDetails.Errors = append(problemDetails.Errors, problem.FieldError{
+					Field:      field.Field,
+					Constraint: field.Rule,
+					Param:      field.Param,
+					Message:    message,
+				})
+				details = append(details, message)
+			}
+			problemDetails.Detail = strings.Join(details, "; ")
+		}
+	case errors.Is(err, user.ErrUserNotFound):
+		problemDetails = http_response.NewProblem(ctx, problemTypeUserNotFound, http.StatusNotFound, messages.UserNotFound, messageKey)
+	case errors.Is(err, user.ErrUsernameTaken):
+		problemDetails = http_response.NewProblem(ctx, problemTypeUsernameTaken, http.StatusConflict, messages.UserUsernameTaken, messageKey)
+	case errors.Is(err, user.ErrForbidden):
+		problemDetails = http_response.NewProblem(ctx, problemTypeForbidden, http.StatusForbidden, messages.UserForbidden, messageKey)
+	case errors.Is(err, user.ErrVersionMismatch):
+		problemDetails = http_response.NewProblem(ctx, problemTypeVersionMismatch, http.StatusPreconditionFailed, messages.UserVersionMismatch, messageKey)
+	default:
+		problemDetails = problem.New(http.StatusInternalServerError, messages.Get(ctx, messageKey))
+	}
+	http_response.WriteProblem(responseWriter, request, handlerName, problemDetails, err)
+}
+
+// readPatchType выбирает способ применения изменений по типу содержимого запроса PATCH.
+// Если тип не поддерживается, отправляется 415 с перечнем типов в заголовке Accept-Patch и возвращается false.
+func readPatchType(responseWriter http.ResponseWriter, request *http.Request, handlerName string) (patchFunc, bool) {
+	contentType := request.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil {
+		if applyPatch, ok := patchFuncs[mediaType]; ok {
+			return applyPatch, true
+		}
+	}
+
+	responseWriter.Header().Set("Accept-Patch", acceptPatch)
+	http_response.WriteError(responseWriter, request, handlerName, http.StatusUnsupportedMediaType, err, messages.PatchUnsupportedType, contentType, acceptPatch)
+	return nil, false
+}
+
+// writePatchError отправляет ошибку применения документа изменений: некорректный JSON — 400,
+// невыполненное условие test — 409, остальные ошибки (неверный путь или операция) — 422
+func writePatchError(responseWriter http.ResponseWriter, request *http.Request, handlerName string, err error) {
+	switch {
+	case errors.Is(err, patch.ErrInvalidDocument):
+		http_response.WriteError(responseWriter, request, handlerName, http.StatusBadRequest, err, messages.PatchInvalid)
+	case errors.Is(err, patch.ErrTestFailed):
+		http_response.WriteError(responseWriter, request, handlerName, http.StatusConflict, err, messages.PatchTestFailed)
+	default:
+		http_response.WriteError(responseWriter, request, handlerName, http.StatusUnprocessableEntity, err, messages.PatchNotApplicable)
+	}
+}
+
+// readQueryParam читает необязательный параметр строки запроса: число, логическое значение
+// или время в формате RFC 3339. Если параметр не задан, возвращается defaultValue.
+// При ошибке ответ уже отправлен и возвращается false.
+func readQueryParam[T int | bool | time.Time](responseWriter http.ResponseWriter, request *http.Request, handlerName string, key string, defaultValue T) (T, bool) {
+	value, err := http_helper.GetQueryParam[T](request.URL.Query(), key)
+	if err != nil {
+		if err == http_helper.ErrParamIsEmpty {
+			return defaultValue, true
+		}
+		fieldError := user.FieldError{Field: key, Rule: "numeric"}
+		switch any(defaultValue).(type) {
+		case bool:
+			fieldError.Rule = "boolean"
+		case time.Time:
+			fieldError = user.FieldError{Field: key, Rule: "datetime", Param: "RFC 3339"}
+		}
+		validationErr := &user.ValidationError{Fields: []user.FieldError{fieldError}}
+		writeServiceError(responseWriter, request, handlerName, messages.RequestInvalidParam, validationErr)
+		return defaultValue, false
+	}
+	return value, true
+}
+
 func NewUserController(userService user.UserService) user.UserController {
 	return &userControllerImpl{
 		userService: userService,
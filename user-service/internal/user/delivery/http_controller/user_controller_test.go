@@ -1,444 +1,1222 @@
-package http_controller
-
-import (
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"net/http/httptest"
-	"net/url"
-	"slices"
-	"strings"
-	"testing"
-
-	"user-service/internal/dto"
-	"user-service/internal/mapper"
-	"user-service/internal/middleware"
-	"user-service/internal/model"
-	"user-service/internal/user"
-	"user-service/internal/user/mock"
-	"user-service/pkg/jwt"
-
-	"github.com/go-playground/assert/v2"
-	"github.com/gorilla/mux"
-)
-
-const jsonRequestTemplate string = "{\"username\": \"%s\", \"password\": \"%s\"}"
-
-var (
-	mockExistOneUserModel *model.UserModel = &model.UserModel{
-		Id:            1,
-		Username:      "user1",
-		Password_Hash: "12345678",
-	}
-	mockExistTwoUserModel *model.UserModel = &model.UserModel{
-		Id:            2,
-		Username:      "user2",
-		Password_Hash: "12345678",
-	}
-	mockFreeUserModel *model.UserModel = &model.UserModel{
-		Id:            0,
-		Username:      "free",
-		Password_Hash: "12345678",
-	}
-)
-
-var mockExistModels = []*model.UserModel{
-	mockExistOneUserModel,
-	mockExistTwoUserModel,
-}
-
-var mockService *mock.MockUserService = &mock.MockUserService{
-	InsertFunc: func(userModel *model.UserModel) (*model.UserModel, error) {
-		return userModel, nil
-	},
-	GetOneFunc: func(id int) (*model.UserModel, error) {
-		for _, userModel := range mockExistModels {
-			if userModel.Id == id {
-				return userModel, nil
-			}
-		}
-		return nil, nil
-	},
-	GetAllFunc: func(page, limit int) ([]*model.UserModel, error) {
-		var offset int
-		if page == 0 {
-			offset = 0
-		} else {
-			offset = limit * page
-		}
-		var count int
-		var result []*model.UserModel
-		for index, value := range mockExistModels {
-			if index < offset {
-				continue
-			}
-			if count >= limit {
-				break
-			}
-			result = append(result, value)
-			count++
-		}
-		return result, nil
-	},
-	UpdateFunc: func(id int, userModel *model.UserModel) (*model.UserModel, error) {
-		return &model.UserModel{
-			Id:            id,
-			Username:      userModel.Username,
-			Password_Hash: userModel.Password_Hash,
-		}, nil
-	},
-	DeleteFunc: func(id int) error {
-		return nil
-	},
-	ExistByIdFunc: func(id int) (bool, error) {
-		for _, userModel := range mockExistModels {
-			if userModel.Id == id {
-				return true, nil
-			}
-		}
-		return false, nil
-	},
-	ExistByUsernameFunc: func(username string) (bool, error) {
-		for _, userModel := range mockExistModels {
-			if userModel.Username == username {
-				return true, nil
-			}
-		}
-		return false, nil
-	},
-	ExistByUsernameAndNotIdFunc: func(username string, id int) (bool, error) {
-		for _, userModel := range mockExistModels {
-			if userModel.Username == username && userModel.Id != id {
-				return true, nil
-			}
-		}
-		return false, nil
-	},
-}
-
-var userController user.UserController = NewUserController(mockService)
-var userRouter *mux.Router = mux.NewRouter()
-var userMapper mapper.UserMapper
-
-var token string
-
-func init() {
-	userRouter = user.SetupUserRoutes(userRouter, userController)
-	jwtCoder := jwt.NewJWTCoder(middleware.Alg, middleware.Secret, middleware.Issuer, middleware.Audience, middleware.ExpirationTimeDuration)
-	tokenInstance := jwtCoder.NewToken("username", "admin", []string{"view", "update", "delete"}...)
-	strToken, err := jwtCoder.Encode(*tokenInstance)
-	if err != nil {
-		panic(err)
-	}
-	token = *strToken
-	fmt.Printf("token: '%s'\n", token)
-}
-
-func TestCreateHandler(t *testing.T) {
-	t.Run("Empty Body", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodPost, "/users", nil)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusBadRequest)
-	})
-
-	t.Run("JSON to UserRequest", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("{}"))
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusBadRequest)
-	})
-
-	t.Run("Check username", func(t *testing.T) {
-		jsonBody := fmt.Sprintf(
-			jsonRequestTemplate,
-			mockExistOneUserModel.Username,
-			mockFreeUserModel.Password_Hash,
-		)
-		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(jsonBody))
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusConflict)
-	})
-
-	t.Run("User created", func(t *testing.T) {
-		jsonBody := fmt.Sprintf(
-			jsonRequestTemplate,
-			mockFreeUserModel.Username,
-			mockFreeUserModel.Password_Hash,
-		)
-		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(jsonBody))
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusCreated)
-
-		var dtoResponse dto.UserResponse
-		err := json.NewDecoder(res.Body).Decode(&dtoResponse)
-		if err != nil {
-			t.Fail()
-		}
-
-		assert.Equal(t, dtoResponse.Username, mockFreeUserModel.Username)
-	})
-
-}
-
-func TestGetAllHandler(t *testing.T) {
-	t.Run("Get all: no param", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/users/all", nil)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusOK)
-
-		var dtoResponses []dto.UserResponse
-		err := json.NewDecoder(res.Body).Decode(&dtoResponses)
-		if err != nil {
-			t.Fail()
-		}
-
-		assert.Equal(t, len(dtoResponses), 2)
-
-		var dtoResponse dto.UserResponse
-
-		dtoResponse = *userMapper.ToDto(*mockExistOneUserModel)
-		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), true)
-
-		dtoResponse = *userMapper.ToDto(*mockExistTwoUserModel)
-		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), true)
-	})
-
-	t.Run("Get all: page 1 limit 1", func(t *testing.T) {
-		params := url.Values{}
-		params.Set("page", "1")
-		params.Set("limit", "1")
-		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusOK)
-
-		var dtoResponses []dto.UserResponse
-		err := json.NewDecoder(res.Body).Decode(&dtoResponses)
-		if err != nil {
-			t.Fail()
-		}
-
-		assert.Equal(t, len(dtoResponses), 1)
-
-		var dtoResponse dto.UserResponse
-
-		dtoResponse = *userMapper.ToDto(*mockExistOneUserModel)
-		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), true)
-
-		dtoResponse = *userMapper.ToDto(*mockExistTwoUserModel)
-		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), false)
-	})
-
-	t.Run("Get all: page 2 limit 1", func(t *testing.T) {
-		params := url.Values{}
-		params.Set("page", "2")
-		params.Set("limit", "1")
-		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusOK)
-
-		var dtoResponses []dto.UserResponse
-		err := json.NewDecoder(res.Body).Decode(&dtoResponses)
-		if err != nil {
-			t.Fail()
-		}
-
-		assert.Equal(t, len(dtoResponses), 1)
-
-		var dtoResponse dto.UserResponse
-
-		dtoResponse = *userMapper.ToDto(*mockExistOneUserModel)
-		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), false)
-
-		dtoResponse = *userMapper.ToDto(*mockExistTwoUserModel)
-		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), true)
-	})
-
-	t.Run("Get all: page 1 limit 2", func(t *testing.T) {
-		params := url.Values{}
-		params.Set("page", "1")
-		params.Set("limit", "2")
-		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusOK)
-
-		var dtoResponses []dto.UserResponse
-		err := json.NewDecoder(res.Body).Decode(&dtoResponses)
-		if err != nil {
-			t.Fail()
-		}
-
-		assert.Equal(t, len(dtoResponses), 2)
-
-		var dtoResponse dto.UserResponse
-
-		dtoResponse = *userMapper.ToDto(*mockExistOneUserModel)
-		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), true)
-
-		dtoResponse = *userMapper.ToDto(*mockExistTwoUserModel)
-		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), true)
-	})
-
-	t.Run("Get all: page 2 limit 2", func(t *testing.T) {
-		params := url.Values{}
-		params.Set("page", "2")
-		params.Set("limit", "2")
-		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusOK)
-
-		var dtoResponses []dto.UserResponse
-		err := json.NewDecoder(res.Body).Decode(&dtoResponses)
-		if err != nil {
-			t.Fail()
-		}
-
-		assert.Equal(t, len(dtoResponses), 0)
-
-		var dtoResponse dto.UserResponse
-
-		dtoResponse = *userMapper.ToDto(*mockExistOneUserModel)
-		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), false)
-
-		dtoResponse = *userMapper.ToDto(*mockExistTwoUserModel)
-		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), false)
-	})
-}
-
-func TestGetOneHandler(t *testing.T) {
-	t.Run("Empty id", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/users/", nil)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusNotFound)
-	})
-
-	t.Run("User not found", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, fmt.Sprint("/users/", mockFreeUserModel.Id), nil)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusNotFound)
-	})
-
-	t.Run("User found", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, fmt.Sprint("/users/", mockExistOneUserModel.Id), nil)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusOK)
-
-		var dtoResponse dto.UserResponse
-		err := json.NewDecoder(res.Body).Decode(&dtoResponse)
-		if err != nil {
-			t.Fail()
-		}
-
-		assert.Equal(t, dtoResponse.Id, mockExistOneUserModel.Id)
-		assert.Equal(t, dtoResponse.Username, mockExistOneUserModel.Username)
-	})
-}
-
-func TestUpdateHandler(t *testing.T) {
-	t.Run("Empty id", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodPut, "/users/", nil)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusNotFound)
-	})
-
-	t.Run("User not found", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodPut, fmt.Sprint("/users/", mockFreeUserModel.Id), nil)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusNotFound)
-	})
-
-	validPath := fmt.Sprint("/users/", mockExistOneUserModel.Id)
-
-	t.Run("Empty Body", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodPut, validPath, nil)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusBadRequest)
-	})
-
-	t.Run("JSON to UserRequest", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodPut, validPath, strings.NewReader("{}"))
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusBadRequest)
-	})
-
-	t.Run("Check username", func(t *testing.T) {
-		jsonBody := fmt.Sprintf(
-			jsonRequestTemplate,
-			mockExistTwoUserModel.Username,
-			mockExistOneUserModel.Password_Hash,
-		)
-		req := httptest.NewRequest(http.MethodPut, validPath, strings.NewReader(jsonBody))
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusConflict)
-	})
-
-	t.Run("User updated", func(t *testing.T) {
-		jsonBody := fmt.Sprintf(
-			jsonRequestTemplate,
-			mockFreeUserModel.Username,
-			mockExistOneUserModel.Password_Hash,
-		)
-		req := httptest.NewRequest(http.MethodPut, validPath, strings.NewReader(jsonBody))
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusOK)
-
-		var dtoResponse dto.UserResponse
-		err := json.NewDecoder(res.Body).Decode(&dtoResponse)
-		if err != nil {
-			t.Fail()
-		}
-
-		assert.Equal(t, dtoResponse.Id, mockExistOneUserModel.Id)
-		assert.Equal(t, dtoResponse.Username, mockFreeUserModel.Username)
-	})
-}
-
-func TestDeleteHandler(t *testing.T) {
-	auth := "Bearer " + token
-	t.Run("No token", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodDelete, "/users/0", nil)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusUnauthorized)
-	})
-
-	t.Run("Empty id", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodDelete, "/users/", nil)
-		req.Header.Add("Authorization", auth)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusNotFound)
-	})
-
-	t.Run("User not found", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodDelete, fmt.Sprint("/users/", mockFreeUserModel.Id), nil)
-		req.Header.Add("Authorization", auth)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusNotFound)
-	})
-
-	t.Run("User deleted", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodDelete, fmt.Sprint("/users/", mockExistOneUserModel.Id), nil)
-		req.Header.Add("Authorization", auth)
-		res := httptest.NewRecorder()
-		userRouter.ServeHTTP(res, req)
-		assert.Equal(t, res.Code, http.StatusNoContent)
-	})
-}
+package http_controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"user-service/internal/dto"
+	"user-service/internal/mapper"
+	"user-service/internal/middleware"
+	"user-service/internal/model"
+	policy_service "user-service/internal/policy/service"
+	"user-service/internal/user"
+	"user-service/internal/user/mock"
+	"user-service/pkg/abac"
+	"user-service/pkg/jwt"
+	"user-service/pkg/patch"
+	"user-service/pkg/problem"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/gorilla/mux"
+)
+
+const jsonRequestTemplate string = "{\"username\": \"%s\", \"password\": \"%s\"}"
+
+var mockUpdatedAt time.Time = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+var (
+	mockExistOneUserModel *model.UserModel = &model.UserModel{
+		Id:            1,
+		Username:      "user1",
+		Password_Hash: "12345678",
+		Tenant:        "acme",
+		Version:       1,
+		UpdatedAt:     mockUpdatedAt,
+	}
+	mockExistTwoUserModel *model.UserModel = &model.UserModel{
+		Id:            2,
+		Username:      "user2",
+		Password_Hash: "12345678",
+		Version:       1,
+		UpdatedAt:     mockUpdatedAt,
+	}
+	mockFreeUserModel *model.UserModel = &model.UserModel{
+		Id:            0,
+		Username:      "free",
+		Password_Hash: "12345678",
+	}
+)
+
+var mockExistModels = []*model.UserModel{
+	mockExistOneUserModel,
+	mockExistTwoUserModel,
+}
+
+var mockService *mock.MockUserService = &mock.MockUserService{
+	InsertFunc: func(ctx context.Context, userModel *model.UserModel) (*model.UserModel, error) {
+		for _, existModel := range mockExistModels {
+			if existModel.Username == userModel.Username {
+				return nil, user.ErrUsernameTaken
+			}
+		}
+		return userModel, nil
+	},
+	GetOneFunc: func(ctx context.Context, id int) (*model.UserModel, error) {
+		for _, userModel := range mockExistModels {
+			if userModel.Id == id {
+				return userModel, nil
+			}
+		}
+		return nil, user.ErrUserNotFound
+	},
+	GetAllFunc: func(ctx context.Context, pageRequest user.PageRequest) (*user.UserPage, error) {
+		// Курсор мока — id последнего пользователя предыдущей страницы
+		var offset int
+		if pageRequest.Cursor != "" {
+			afterId, err := strconv.Atoi(pageRequest.Cursor)
+			if err != nil {
+				return nil, &user.ValidationError{Fields: []user.FieldError{{Field: "cursor", Rule: "cursor"}}}
+			}
+			offset = slices.IndexFunc(mockExistModels, func(existModel *model.UserModel) bool { return existModel.Id > afterId })
+			if offset < 0 {
+				offset = len(mockExistModels)
+			}
+		} else if pageRequest.Page > 1 {
+			offset = pageRequest.Limit * (pageRequest.Page - 1)
+		}
+		userPage := &user.UserPage{}
+		if pageRequest.WithTotal {
+			userPage.Total = len(mockExistModels)
+		}
+		for index, value := range mockExistModels {
+			if index < offset || !strings.HasPrefix(value.Username, pageRequest.Filter.UsernamePrefix) {
+				continue
+			}
+			if len(userPage.Users) >= pageRequest.Limit {
+				userPage.Next = strconv.Itoa(userPage.Users[len(userPage.Users)-1].Id)
+				break
+			}
+			userPage.Users = append(userPage.Users, value)
+		}
+		return userPage, nil
+	},
+	UpdateFunc: func(ctx context.Context, id int, userModel *model.UserModel) (*model.UserModel, error) {
+		index := slices.IndexFunc(mockExistModels, func(existModel *model.UserModel) bool { return existModel.Id == id })
+		if index < 0 {
+			return nil, user.ErrUserNotFound
+		}
+		for _, existModel := range mockExistModels {
+			if existModel.Username == userModel.Username && existModel.Id != id {
+				return nil, user.ErrUsernameTaken
+			}
+		}
+		if userModel.Version != 0 && userModel.Version != mockExistModels[index].Version {
+			return nil, user.ErrVersionMismatch
+		}
+		// Пустые поля остаются без изменений, как в UserRepository.Update
+		updatedModel := *mockExistModels[index]
+		updatedModel.Version++
+		if userModel.Username != "" {
+			updatedModel.Username = userModel.Username
+		}
+		if userModel.Password_Hash != "" {
+			updatedModel.Password_Hash = userModel.Password_Hash
+		}
+		return &updatedModel, nil
+	},
+	DeleteFunc: func(ctx context.Context, id int, version int) error {
+		index := slices.IndexFunc(mockExistModels, func(existModel *model.UserModel) bool { return existModel.Id == id })
+		if index < 0 {
+			return user.ErrUserNotFound
+		}
+		if version != 0 && version != mockExistModels[index].Version {
+			return user.ErrVersionMismatch
+		}
+		return nil
+	},
+	SetTenantFunc: func(ctx context.Context, id int, tenant string, version int) (*model.UserModel, error) {
+		index := slices.IndexFunc(mockExistModels, func(existModel *model.UserModel) bool { return existModel.Id == id })
+		if index < 0 {
+			return nil, user.ErrUserNotFound
+		}
+		if version != 0 && version != mockExistModels[index].Version {
+			return nil, user.ErrVersionMismatch
+		}
+		updatedModel := *mockExistModels[index]
+		updatedModel.Version++
+		updatedModel.Tenant = tenant
+		return &updatedModel, nil
+	},
+}
+
+// policyUserRepository отдает правилам доступа пользователей из мока сервиса
+type policyUserRepository struct {
+	user.UserRepository
+}
+
+func (policyUserRepository) GetOne(ctx context.Context, id int) (*model.UserModel, error) {
+	return mockService.GetOneFunc(ctx, id)
+}
+
+var userController user.UserController = NewUserController(mockService)
+var userRouter *mux.Router = mux.NewRouter()
+var userMapper mapper.UserMapper
+
+var token string
+var readerToken string
+var ownerToken string
+var supportToken string
+var otherSupportToken string
+var noTenantSupportToken string
+
+func init() {
+	userRouter.Use(middleware.Localize)
+	policyEngine, err := abac.Load("../../../../config/policies.yml")
+	if err != nil {
+		panic(err)
+	}
+	userRouter = user.SetupUserRoutes(userRouter, userController, policy_service.NewPolicyService(policyEngine, policyUserRepository{}))
+	jwtCoder := jwt.NewJWTCoder(middleware.Alg, middleware.Secret, middleware.Issuer, middleware.Audience, middleware.ExpirationTimeDuration)
+	tokenInstance := jwtCoder.NewToken("username", []string{"admin"}, []string{"users:read", "users:write", "users:delete"}...)
+	strToken, err := jwtCoder.Encode(*tokenInstance)
+	if err != nil {
+		panic(err)
+	}
+	token = *strToken
+	fmt.Printf("token: '%s'\n", token)
+
+	strToken, err = jwtCoder.Encode(*jwtCoder.NewToken("reader", []string{"user"}, "users:read"))
+	if err != nil {
+		panic(err)
+	}
+	readerToken = *strToken
+
+	strToken, err = jwtCoder.Encode(*jwtCoder.NewToken(fmt.Sprint(mockExistTwoUserModel.Id), []string{"user"}))
+	if err != nil {
+		panic(err)
+	}
+	ownerToken = *strToken
+
+	// Поддержка получает право users:write, чтобы проверить, что запрет на смену пароля сильнее разрешения
+	for tenant, supportTokenRef := range map[string]*string{"acme": &supportToken, "globex": &otherSupportToken, "": &noTenantSupportToken} {
+		supportTokenInstance := jwtCoder.NewToken("100", []string{"support"}, "users:write")
+		supportTokenInstance.Payload.Tenant = tenant
+		strToken, err = jwtCoder.Encode(*supportTokenInstance)
+		if err != nil {
+			panic(err)
+		}
+		*supportTokenRef = *strToken
+	}
+}
+
+func TestCreateHandler(t *testing.T) {
+	t.Run("No token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("{}"))
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("No permission", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("{}"))
+		req.Header.Add("Authorization", "Bearer "+readerToken)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusForbidden)
+	})
+
+	t.Run("Empty Body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/users", nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("JSON to UserRequest", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("{}"))
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+		assert.Equal(t, res.Header().Get("Content-Type"), problem.ContentType)
+
+		var problemDetails problem.Problem
+		err := json.NewDecoder(res.Body).Decode(&problemDetails)
+		if err != nil {
+			t.Error(err)
+		}
+
+		assert.Equal(t, problemDetails.Type, problemTypeValidation)
+		assert.Equal(t, problemDetails.Status, http.StatusBadRequest)
+		assert.Equal(t, problemDetails.Instance, "/users")
+		assert.Equal(t, slices.Contains(problemDetails.Errors, problem.FieldError{
+			Field:      "username",
+			Constraint: "required",
+			Message:    "Поле username обязательно",
+		}), true)
+		assert.Equal(t, slices.Contains(problemDetails.Errors, problem.FieldError{
+			Field:      "password",
+			Constraint: "required",
+			Message:    "Поле password обязательно",
+		}), true)
+	})
+
+	t.Run("Accept-Language en", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRequestTemplate, "ab", mockFreeUserModel.Password_Hash)
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+token)
+		req.Header.Add("Accept-Language", "de;q=0.9, en-US;q=0.8, ru;q=0.5")
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+		assert.Equal(t, res.Header().Get("Content-Language"), "en")
+
+		var problemDetails problem.Problem
+		err := json.NewDecoder(res.Body).Decode(&problemDetails)
+		if err != nil {
+			t.Error(err)
+		}
+
+		assert.Equal(t, problemDetails.Title, "Invalid user data")
+		assert.Equal(t, problemDetails.Errors, []problem.FieldError{{
+			Field:      "username",
+			Constraint: "min",
+			Param:      "3",
+			Message:    "username must be at least 3 characters long",
+		}})
+	})
+
+	t.Run("Check username", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(
+			jsonRequestTemplate,
+			mockExistOneUserModel.Username,
+			mockFreeUserModel.Password_Hash,
+		)
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusConflict)
+	})
+
+	t.Run("User created", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(
+			jsonRequestTemplate,
+			mockFreeUserModel.Username,
+			mockFreeUserModel.Password_Hash,
+		)
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusCreated)
+
+		var dtoResponse dto.UserResponse
+		err := json.NewDecoder(res.Body).Decode(&dtoResponse)
+		if err != nil {
+			t.Fail()
+		}
+
+		assert.Equal(t, dtoResponse.Username, mockFreeUserModel.Username)
+	})
+
+}
+
+func TestGetAllHandler(t *testing.T) {
+	t.Run("Get all: no param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/all", nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponses []dto.UserResponse
+		err := json.NewDecoder(res.Body).Decode(&dtoResponses)
+		if err != nil {
+			t.Fail()
+		}
+
+		assert.Equal(t, len(dtoResponses), 2)
+
+		var dtoResponse dto.UserResponse
+
+		dtoResponse = *userMapper.ToDto(*mockExistOneUserModel)
+		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), true)
+
+		dtoResponse = *userMapper.ToDto(*mockExistTwoUserModel)
+		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), true)
+	})
+
+	t.Run("Get all: page 1 limit 1", func(t *testing.T) {
+		params := url.Values{}
+		params.Set("page", "1")
+		params.Set("limit", "1")
+		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponses []dto.UserResponse
+		err := json.NewDecoder(res.Body).Decode(&dtoResponses)
+		if err != nil {
+			t.Fail()
+		}
+
+		assert.Equal(t, len(dtoResponses), 1)
+
+		var dtoResponse dto.UserResponse
+
+		dtoResponse = *userMapper.ToDto(*mockExistOneUserModel)
+		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), true)
+
+		dtoResponse = *userMapper.ToDto(*mockExistTwoUserModel)
+		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), false)
+	})
+
+	t.Run("Get all: page 2 limit 1", func(t *testing.T) {
+		params := url.Values{}
+		params.Set("page", "2")
+		params.Set("limit", "1")
+		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponses []dto.UserResponse
+		err := json.NewDecoder(res.Body).Decode(&dtoResponses)
+		if err != nil {
+			t.Fail()
+		}
+
+		assert.Equal(t, len(dtoResponses), 1)
+
+		var dtoResponse dto.UserResponse
+
+		dtoResponse = *userMapper.ToDto(*mockExistOneUserModel)
+		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), false)
+
+		dtoResponse = *userMapper.ToDto(*mockExistTwoUserModel)
+		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), true)
+	})
+
+	t.Run("Get all: page 1 limit 2", func(t *testing.T) {
+		params := url.Values{}
+		params.Set("page", "1")
+		params.Set("limit", "2")
+		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponses []dto.UserResponse
+		err := json.NewDecoder(res.Body).Decode(&dtoResponses)
+		if err != nil {
+			t.Fail()
+		}
+
+		assert.Equal(t, len(dtoResponses), 2)
+
+		var dtoResponse dto.UserResponse
+
+		dtoResponse = *userMapper.ToDto(*mockExistOneUserModel)
+		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), true)
+
+		dtoResponse = *userMapper.ToDto(*mockExistTwoUserModel)
+		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), true)
+	})
+
+	t.Run("Get all: page 2 limit 2", func(t *testing.T) {
+		params := url.Values{}
+		params.Set("page", "2")
+		params.Set("limit", "2")
+		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponses []dto.UserResponse
+		err := json.NewDecoder(res.Body).Decode(&dtoResponses)
+		if err != nil {
+			t.Fail()
+		}
+
+		assert.Equal(t, len(dtoResponses), 0)
+
+		var dtoResponse dto.UserResponse
+
+		dtoResponse = *userMapper.ToDto(*mockExistOneUserModel)
+		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), false)
+
+		dtoResponse = *userMapper.ToDto(*mockExistTwoUserModel)
+		assert.Equal(t, slices.Contains(dtoResponses, dtoResponse), false)
+	})
+
+	t.Run("Get all: cursor", func(t *testing.T) {
+		params := url.Values{}
+		params.Set("limit", "1")
+		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponses []dto.UserResponse
+		if err := json.NewDecoder(res.Body).Decode(&dtoResponses); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, dtoResponses, []dto.UserResponse{*userMapper.ToDto(*mockExistOneUserModel)})
+		nextCursor := res.Header().Get("X-Next-Cursor")
+		assert.NotEqual(t, nextCursor, "")
+
+		params.Set("cursor", nextCursor)
+		req = httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res = httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		dtoResponses = nil
+		if err := json.NewDecoder(res.Body).Decode(&dtoResponses); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, dtoResponses, []dto.UserResponse{*userMapper.ToDto(*mockExistTwoUserModel)})
+		assert.Equal(t, res.Header().Get("X-Next-Cursor"), "")
+	})
+
+	t.Run("Get all: envelope", func(t *testing.T) {
+		params := url.Values{}
+		params.Set("limit", "1")
+		params.Set("envelope", "true")
+		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var pageResponse dto.UserPageResponse
+		if err := json.NewDecoder(res.Body).Decode(&pageResponse); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, pageResponse.Items, []dto.UserResponse{*userMapper.ToDto(*mockExistOneUserModel)})
+		assert.Equal(t, pageResponse.Total, 2)
+		assert.Equal(t, pageResponse.Page, 1)
+		assert.Equal(t, pageResponse.Limit, 1)
+		assert.Equal(t, pageResponse.HasNext, true)
+		assert.Equal(t, pageResponse.Next, strconv.Itoa(mockExistOneUserModel.Id))
+
+		params.Set("cursor", pageResponse.Next)
+		req = httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res = httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		pageResponse = dto.UserPageResponse{}
+		if err := json.NewDecoder(res.Body).Decode(&pageResponse); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, pageResponse.Items, []dto.UserResponse{*userMapper.ToDto(*mockExistTwoUserModel)})
+		assert.Equal(t, pageResponse.Page, 0)
+		assert.Equal(t, pageResponse.HasNext, false)
+	})
+
+	t.Run("Get all: Link", func(t *testing.T) {
+		params := url.Values{}
+		params.Set("page", "1")
+		params.Set("limit", "1")
+		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		assert.Equal(t, res.Header().Values("Link"), []string{
+			`</users/all?limit=1>; rel="first"`,
+			`</users/all?cursor=1&limit=1>; rel="next"`,
+		})
+	})
+
+	t.Run("Get all: username prefix", func(t *testing.T) {
+		params := url.Values{}
+		params.Set("username_prefix", mockExistTwoUserModel.Username)
+		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponses []dto.UserResponse
+		if err := json.NewDecoder(res.Body).Decode(&dtoResponses); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, dtoResponses, []dto.UserResponse{*userMapper.ToDto(*mockExistTwoUserModel)})
+	})
+
+	t.Run("Get all: invalid created_after", func(t *testing.T) {
+		params := url.Values{}
+		params.Set("created_after", "2024-03-01")
+		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+
+		var problemDetails problem.Problem
+		if err := json.NewDecoder(res.Body).Decode(&problemDetails); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, problemDetails.Errors[0].Field, "created_after")
+		assert.Equal(t, problemDetails.Errors[0].Constraint, "datetime")
+	})
+
+	t.Run("Get all: invalid envelope", func(t *testing.T) {
+		params := url.Values{}
+		params.Set("envelope", "yes")
+		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Get all: invalid cursor", func(t *testing.T) {
+		params := url.Values{}
+		params.Set("cursor", "invalid")
+		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+
+		var problemDetails problem.Problem
+		if err := json.NewDecoder(res.Body).Decode(&problemDetails); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, problemDetails.Type, "/problems/validation-error")
+		assert.Equal(t, problemDetails.Errors[0].Field, "cursor")
+	})
+
+	t.Run("Get all: If-None-Match", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/all", nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+		etag := res.Header().Get("ETag")
+		assert.NotEqual(t, etag, "")
+
+		req = httptest.NewRequest(http.MethodGet, "/users/all", nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		req.Header.Add("If-None-Match", etag)
+		res = httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNotModified)
+
+		params := url.Values{}
+		params.Set("limit", "1")
+		req = httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		req.Header.Add("If-None-Match", etag)
+		res = httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+	})
+
+	t.Run("Get all: invalid limit", func(t *testing.T) {
+		params := url.Values{}
+		params.Set("limit", "ten")
+		req := httptest.NewRequest(http.MethodGet, "/users/all?"+params.Encode(), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+}
+
+func TestGetOneHandler(t *testing.T) {
+	t.Run("Empty id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/", nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprint("/users/", mockFreeUserModel.Id), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNotFound)
+		assert.Equal(t, res.Header().Get("Content-Type"), problem.ContentType)
+
+		var problemDetails problem.Problem
+		err := json.NewDecoder(res.Body).Decode(&problemDetails)
+		if err != nil {
+			t.Error(err)
+		}
+
+		assert.Equal(t, problemDetails.Type, problemTypeUserNotFound)
+		assert.Equal(t, problemDetails.Status, http.StatusNotFound)
+		assert.Equal(t, problemDetails.Instance, fmt.Sprint("/users/", mockFreeUserModel.Id))
+	})
+
+	t.Run("User found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprint("/users/", mockExistOneUserModel.Id), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponse dto.UserResponse
+		err := json.NewDecoder(res.Body).Decode(&dtoResponse)
+		if err != nil {
+			t.Fail()
+		}
+
+		assert.Equal(t, dtoResponse.Id, mockExistOneUserModel.Id)
+		assert.Equal(t, dtoResponse.Username, mockExistOneUserModel.Username)
+		assert.Equal(t, res.Header().Get("ETag"), `"1"`)
+		assert.Equal(t, res.Header().Get("Last-Modified"), mockUpdatedAt.Format(http.TimeFormat))
+		assert.Equal(t, res.Header().Get("Cache-Control"), cacheControl)
+	})
+
+	t.Run("If-None-Match", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprint("/users/", mockExistOneUserModel.Id), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		req.Header.Add("If-None-Match", `"1"`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNotModified)
+		assert.Equal(t, res.Header().Get("ETag"), `"1"`)
+		assert.Equal(t, res.Body.Len(), 0)
+	})
+
+	t.Run("If-None-Match changed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprint("/users/", mockExistOneUserModel.Id), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		req.Header.Add("If-None-Match", `"0"`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+	})
+
+	t.Run("If-Modified-Since", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprint("/users/", mockExistOneUserModel.Id), nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		req.Header.Add("If-Modified-Since", mockUpdatedAt.Format(http.TimeFormat))
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNotModified)
+	})
+}
+
+func TestUpdateHandler(t *testing.T) {
+	t.Run("Empty id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/users/", nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRequestTemplate, mockFreeUserModel.Username, mockFreeUserModel.Password_Hash)
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprint("/users/", mockFreeUserModel.Id), strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	})
+
+	validPath := fmt.Sprint("/users/", mockExistOneUserModel.Id)
+
+	t.Run("Empty Body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, validPath, nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("JSON to UserRequest", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, validPath, strings.NewReader("{}"))
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Check username", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(
+			jsonRequestTemplate,
+			mockExistTwoUserModel.Username,
+			mockExistOneUserModel.Password_Hash,
+		)
+		req := httptest.NewRequest(http.MethodPut, validPath, strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusConflict)
+	})
+
+	t.Run("User updated", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(
+			jsonRequestTemplate,
+			mockFreeUserModel.Username,
+			mockExistOneUserModel.Password_Hash,
+		)
+		req := httptest.NewRequest(http.MethodPut, validPath, strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+token)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponse dto.UserResponse
+		err := json.NewDecoder(res.Body).Decode(&dtoResponse)
+		if err != nil {
+			t.Fail()
+		}
+
+		assert.Equal(t, dtoResponse.Id, mockExistOneUserModel.Id)
+		assert.Equal(t, dtoResponse.Username, mockFreeUserModel.Username)
+	})
+
+	t.Run("If-Match mismatch", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRequestTemplate, mockFreeUserModel.Username, mockExistOneUserModel.Password_Hash)
+		req := httptest.NewRequest(http.MethodPut, validPath, strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+token)
+		req.Header.Add("If-Match", `"2"`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusPreconditionFailed)
+
+		var problemDetails problem.Problem
+		err := json.NewDecoder(res.Body).Decode(&problemDetails)
+		if err != nil {
+			t.Error(err)
+		}
+
+		assert.Equal(t, problemDetails.Type, problemTypeVersionMismatch)
+	})
+
+	t.Run("If-Match updated", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRequestTemplate, mockFreeUserModel.Username, mockExistOneUserModel.Password_Hash)
+		req := httptest.NewRequest(http.MethodPut, validPath, strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+token)
+		req.Header.Add("If-Match", `"1"`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+		assert.Equal(t, res.Header().Get("ETag"), `"2"`)
+	})
+}
+
+func TestPatchHandler(t *testing.T) {
+	validPath := fmt.Sprint("/users/", mockExistOneUserModel.Id)
+
+	newPatchRequest := func(path string, contentType string, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPatch, path, strings.NewReader(body))
+		req.Header.Add("Authorization", "Bearer "+token)
+		req.Header.Add("Content-Type", contentType)
+		return req
+	}
+
+	t.Run("Unsupported media type", func(t *testing.T) {
+		req := newPatchRequest(validPath, "application/json", `{"username": "renamed"}`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusUnsupportedMediaType)
+		assert.Equal(t, res.Header().Get("Accept-Patch"), acceptPatch)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		req := newPatchRequest(fmt.Sprint("/users/", mockFreeUserModel.Id), patch.MergePatchContentType, `{"username": "renamed"}`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	})
+
+	t.Run("Merge patch: username only", func(t *testing.T) {
+		req := newPatchRequest(validPath, patch.MergePatchContentType, `{"username": "renamed"}`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponse dto.UserResponse
+		err := json.NewDecoder(res.Body).Decode(&dtoResponse)
+		if err != nil {
+			t.Error(err)
+		}
+
+		assert.Equal(t, dtoResponse.Id, mockExistOneUserModel.Id)
+		assert.Equal(t, dtoResponse.Username, "renamed")
+	})
+
+	t.Run("Merge patch: password only", func(t *testing.T) {
+		req := newPatchRequest(validPath, patch.MergePatchContentType, `{"password": "new-password"}`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponse dto.UserResponse
+		err := json.NewDecoder(res.Body).Decode(&dtoResponse)
+		if err != nil {
+			t.Error(err)
+		}
+
+		assert.Equal(t, dtoResponse.Username, mockExistOneUserModel.Username)
+	})
+
+	t.Run("Merge patch: invalid password", func(t *testing.T) {
+		req := newPatchRequest(validPath, patch.MergePatchContentType, `{"password": "short"}`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+
+		var problemDetails problem.Problem
+		err := json.NewDecoder(res.Body).Decode(&problemDetails)
+		if err != nil {
+			t.Error(err)
+		}
+
+		assert.Equal(t, len(problemDetails.Errors), 1)
+		assert.Equal(t, problemDetails.Errors[0].Field, "password")
+		assert.Equal(t, problemDetails.Errors[0].Constraint, "min")
+	})
+
+	t.Run("Merge patch: remove username", func(t *testing.T) {
+		req := newPatchRequest(validPath, patch.MergePatchContentType, `{"username": null}`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+
+		var problemDetails problem.Problem
+		err := json.NewDecoder(res.Body).Decode(&problemDetails)
+		if err != nil {
+			t.Error(err)
+		}
+
+		assert.Equal(t, len(problemDetails.Errors), 1)
+		assert.Equal(t, problemDetails.Errors[0].Field, "username")
+		assert.Equal(t, problemDetails.Errors[0].Constraint, "required")
+	})
+
+	t.Run("Merge patch: check username", func(t *testing.T) {
+		req := newPatchRequest(validPath, patch.MergePatchContentType, fmt.Sprintf(`{"username": "%s"}`, mockExistTwoUserModel.Username))
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusConflict)
+	})
+
+	t.Run("Merge patch: invalid JSON", func(t *testing.T) {
+		req := newPatchRequest(validPath, patch.MergePatchContentType, `{"username": `)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("JSON Patch: replace username", func(t *testing.T) {
+		body := fmt.Sprintf(
+			`[{"op": "test", "path": "/username", "value": "%s"}, {"op": "replace", "path": "/username", "value": "patched"}]`,
+			mockExistOneUserModel.Username,
+		)
+		req := newPatchRequest(validPath, patch.JSONPatchContentType, body)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponse dto.UserResponse
+		err := json.NewDecoder(res.Body).Decode(&dtoResponse)
+		if err != nil {
+			t.Error(err)
+		}
+
+		assert.Equal(t, dtoResponse.Username, "patched")
+	})
+
+	t.Run("JSON Patch: test failed", func(t *testing.T) {
+		req := newPatchRequest(validPath, patch.JSONPatchContentType, `[{"op": "test", "path": "/username", "value": "other"}]`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusConflict)
+	})
+
+	t.Run("JSON Patch: missing path", func(t *testing.T) {
+		req := newPatchRequest(validPath, patch.JSONPatchContentType, `[{"op": "remove", "path": "/password"}]`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("If-Match mismatch", func(t *testing.T) {
+		req := newPatchRequest(validPath, patch.MergePatchContentType, `{"username": "renamed"}`)
+		req.Header.Add("If-Match", `W/"1"`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusPreconditionFailed)
+	})
+
+	t.Run("If-Match patched", func(t *testing.T) {
+		req := newPatchRequest(validPath, patch.MergePatchContentType, `{"username": "renamed"}`)
+		req.Header.Add("If-Match", `"1"`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+		assert.Equal(t, res.Header().Get("ETag"), `"2"`)
+	})
+}
+
+func TestDeleteHandler(t *testing.T) {
+	auth := "Bearer " + token
+	t.Run("No token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/users/0", nil)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Empty id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/users/", nil)
+		req.Header.Add("Authorization", auth)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprint("/users/", mockFreeUserModel.Id), nil)
+		req.Header.Add("Authorization", auth)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	})
+
+	t.Run("User deleted", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprint("/users/", mockExistOneUserModel.Id), nil)
+		req.Header.Add("Authorization", auth)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNoContent)
+	})
+
+	t.Run("If-Match mismatch", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprint("/users/", mockExistOneUserModel.Id), nil)
+		req.Header.Add("Authorization", auth)
+		req.Header.Add("If-Match", `"2"`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusPreconditionFailed)
+	})
+
+	t.Run("If-Match deleted", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprint("/users/", mockExistOneUserModel.Id), nil)
+		req.Header.Add("Authorization", auth)
+		req.Header.Add("If-Match", `"1"`)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNoContent)
+	})
+}
+
+func TestSetTenantHandler(t *testing.T) {
+	path := fmt.Sprint("/users/", mockExistTwoUserModel.Id, "/tenant")
+
+	serve := func(path string, bearer string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(body))
+		req.Header.Add("Authorization", "Bearer "+bearer)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		return res
+	}
+
+	t.Run("Not admin", func(t *testing.T) {
+		res := serve(path, readerToken, `{"tenant":"acme"}`)
+		assert.Equal(t, res.Code, http.StatusForbidden)
+	})
+
+	t.Run("Support cannot move user", func(t *testing.T) {
+		res := serve(path, supportToken, `{"tenant":"acme"}`)
+		assert.Equal(t, res.Code, http.StatusForbidden)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		res := serve(fmt.Sprint("/users/", mockFreeUserModel.Id, "/tenant"), token, `{"tenant":"acme"}`)
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	})
+
+	t.Run("Tenant too long", func(t *testing.T) {
+		res := serve(path, token, fmt.Sprintf(`{"tenant":"%s"}`, strings.Repeat("a", 65)))
+		assert.Equal(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Tenant set", func(t *testing.T) {
+		res := serve(path, token, `{"tenant":"acme"}`)
+		assert.Equal(t, res.Code, http.StatusOK)
+		assert.Equal(t, res.Header().Get("ETag"), versionETag(mockExistTwoUserModel.Version+1))
+
+		var dtoResponse dto.UserResponse
+		assert.Equal(t, json.Unmarshal(res.Body.Bytes(), &dtoResponse), nil)
+		assert.Equal(t, dtoResponse.Tenant, "acme")
+	})
+
+	t.Run("Tenant removed", func(t *testing.T) {
+		res := serve(path, token, `{"tenant":""}`)
+		assert.Equal(t, res.Code, http.StatusOK)
+		assert.Equal(t, strings.Contains(res.Body.String(), "tenant"), false)
+	})
+}
+
+func TestOwnership(t *testing.T) {
+	ownPath := fmt.Sprint("/users/", mockExistTwoUserModel.Id)
+	otherPath := fmt.Sprint("/users/", mockExistOneUserModel.Id)
+	jsonBody := fmt.Sprintf(jsonRequestTemplate, "renamed", mockExistTwoUserModel.Password_Hash)
+
+	t.Run("Get other user", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, otherPath, nil)
+		req.Header.Add("Authorization", "Bearer "+ownerToken)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusForbidden)
+	})
+
+	t.Run("Update other user", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, otherPath, strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+ownerToken)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusForbidden)
+	})
+
+	t.Run("Update other user without token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, otherPath, strings.NewReader(jsonBody))
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Update own record", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, ownPath, strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+ownerToken)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+	})
+
+	t.Run("Delete other user", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, otherPath, nil)
+		req.Header.Add("Authorization", "Bearer "+ownerToken)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusForbidden)
+	})
+}
+
+func TestSupportPolicy(t *testing.T) {
+	tenantPath := fmt.Sprint("/users/", mockExistOneUserModel.Id)
+	noTenantPath := fmt.Sprint("/users/", mockExistTwoUserModel.Id)
+
+	serve := func(method string, path string, bearer string, body string, contentType string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Add("Authorization", "Bearer "+bearer)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		return res.Code
+	}
+
+	t.Run("Read user in own tenant", func(t *testing.T) {
+		assert.Equal(t, serve(http.MethodGet, tenantPath, supportToken, "", ""), http.StatusOK)
+	})
+
+	t.Run("Read user in other tenant", func(t *testing.T) {
+		assert.Equal(t, serve(http.MethodGet, tenantPath, otherSupportToken, "", ""), http.StatusForbidden)
+	})
+
+	t.Run("Empty tenant is not a match", func(t *testing.T) {
+		assert.Equal(t, serve(http.MethodGet, noTenantPath, noTenantSupportToken, "", ""), http.StatusForbidden)
+	})
+
+	t.Run("Change password with PUT", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRequestTemplate, "renamed", "new-password")
+		assert.Equal(t, serve(http.MethodPut, tenantPath, supportToken, jsonBody, ""), http.StatusForbidden)
+	})
+
+	t.Run("Change password with PATCH", func(t *testing.T) {
+		assert.Equal(t, serve(http.MethodPatch, tenantPath, supportToken, `{"password":"new-password"}`, patch.MergePatchContentType), http.StatusForbidden)
+		assert.Equal(t, serve(http.MethodPatch, tenantPath, supportToken, `[{"op":"add","path":"/password","value":"new-password"}]`, patch.JSONPatchContentType), http.StatusForbidden)
+	})
+
+	t.Run("Change password with mixed-case key", func(t *testing.T) {
+		assert.Equal(t, serve(http.MethodPatch, tenantPath, supportToken, `{"Password":"new-password"}`, patch.MergePatchContentType), http.StatusForbidden)
+		assert.Equal(t, serve(http.MethodPatch, tenantPath, supportToken, `{"PASSWORD":"new-password"}`, patch.MergePatchContentType), http.StatusForbidden)
+		assert.Equal(t, serve(http.MethodPatch, tenantPath, supportToken, `[{"op":"add","path":"/Password","value":"new-password"}]`, patch.JSONPatchContentType), http.StatusForbidden)
+	})
+
+	t.Run("Change username with PATCH", func(t *testing.T) {
+		assert.Equal(t, serve(http.MethodPatch, tenantPath, supportToken, `{"username":"renamed"}`, patch.MergePatchContentType), http.StatusOK)
+	})
+}
+
+func TestMeHandlers(t *testing.T) {
+	t.Run("No token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/me", nil)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Get me", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/me", nil)
+		req.Header.Add("Authorization", "Bearer "+ownerToken)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponse dto.UserResponse
+		if err := json.NewDecoder(res.Body).Decode(&dtoResponse); err != nil {
+			t.Fail()
+		}
+		assert.Equal(t, dtoResponse.Id, mockExistTwoUserModel.Id)
+	})
+
+	t.Run("Update me", func(t *testing.T) {
+		jsonBody := fmt.Sprintf(jsonRequestTemplate, "renamed", mockExistTwoUserModel.Password_Hash)
+		req := httptest.NewRequest(http.MethodPut, "/users/me", strings.NewReader(jsonBody))
+		req.Header.Add("Authorization", "Bearer "+ownerToken)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusOK)
+
+		var dtoResponse dto.UserResponse
+		if err := json.NewDecoder(res.Body).Decode(&dtoResponse); err != nil {
+			t.Fail()
+		}
+		assert.Equal(t, dtoResponse.Id, mockExistTwoUserModel.Id)
+		assert.Equal(t, dtoResponse.Username, "renamed")
+	})
+
+	t.Run("Delete me", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/users/me", nil)
+		req.Header.Add("Authorization", "Bearer "+ownerToken)
+		res := httptest.NewRecorder()
+		userRouter.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNoContent)
+	})
+}
@@ -1,55 +1,48 @@
 package mock
 
-import "user-service/internal/model"
+import (
+	"context"
+	"user-service/internal/model"
+	"user-service/internal/user"
+)
 
 // Создаем мок-реализацию
 type MockUserService struct {
-	InsertFunc                  func(model *model.UserModel) (*model.UserModel, error)
-	GetAllFunc                  func(page, limit int) ([]*model.UserModel, error)
-	GetOneFunc                  func(id int) (*model.UserModel, error)
-	UpdateFunc                  func(id int, model *model.UserModel) (*model.UserModel, error)
-	DeleteFunc                  func(id int) error
-	ExistByIdFunc               func(id int) (bool, error)
-	ExistByUsernameFunc         func(username string) (bool, error)
-	ExistByUsernameAndNotIdFunc func(username string, id int) (bool, error)
+	InsertFunc func(ctx context.Context, model *model.UserModel) (*model.UserModel, error)
+	GetAllFunc func(ctx context.Context, pageRequest user.PageRequest) (*user.UserPage, error)
+	GetOneFunc func(ctx context.Context, id int) (*model.UserModel, error)
+	UpdateFunc func(ctx context.Context, id int, model *model.UserModel) (*model.UserModel, error)
+	DeleteFunc func(ctx context.Context, id int, version int) error
+
+	SetTenantFunc func(ctx context.Context, id int, tenant string, version int) (*model.UserModel, error)
 }
 
 // Insert implements service.UserService.
-func (m *MockUserService) Create(userModel *model.UserModel) (*model.UserModel, error) {
-	return m.InsertFunc(userModel)
+func (m *MockUserService) Create(ctx context.Context, userModel *model.UserModel) (*model.UserModel, error) {
+	return m.InsertFunc(ctx, userModel)
 }
 
 // GetAll implements service.UserService.
-func (m *MockUserService) GetAll(page, limit int) ([]*model.UserModel, error) {
-	return m.GetAllFunc(page, limit)
+func (m *MockUserService) GetAll(ctx context.Context, pageRequest user.PageRequest) (*user.UserPage, error) {
+	return m.GetAllFunc(ctx, pageRequest)
 }
 
 // GetOne implements service.UserService.
-func (m *MockUserService) GetOne(id int) (*model.UserModel, error) {
-	return m.GetOneFunc(id)
+func (m *MockUserService) GetOne(ctx context.Context, id int) (*model.UserModel, error) {
+	return m.GetOneFunc(ctx, id)
 }
 
 // Update implements service.UserService.
-func (m *MockUserService) Update(id int, userModel *model.UserModel) (*model.UserModel, error) {
-	return m.UpdateFunc(id, userModel)
+func (m *MockUserService) Update(ctx context.Context, id int, userModel *model.UserModel) (*model.UserModel, error) {
+	return m.UpdateFunc(ctx, id, userModel)
 }
 
 // Delete implements service.UserService.
-func (m *MockUserService) Delete(id int) error {
-	return m.DeleteFunc(id)
-}
-
-// ExistById implements service.UserService.
-func (m *MockUserService) ExistById(id int) (bool, error) {
-	return m.ExistByIdFunc(id)
-}
-
-// ExistByUsername implements service.UserService.
-func (m *MockUserService) ExistByUsername(username string) (bool, error) {
-	return m.ExistByUsernameFunc(username)
+func (m *MockUserService) Delete(ctx context.Context, id int, version int) error {
+	return m.DeleteFunc(ctx, id, version)
 }
 
-// ExistByUsernameAndNotId implements service.UserService.
-func (m *MockUserService) ExistByUsernameAndNotId(username string, id int) (bool, error) {
-	return m.ExistByUsernameAndNotIdFunc(username, id)
+// SetTenant implements service.UserService.
+func (m *MockUserService) SetTenant(ctx context.Context, id int, tenant string, version int) (*model.UserModel, error) {
+	return m.SetTenantFunc(ctx, id, tenant, version)
 }
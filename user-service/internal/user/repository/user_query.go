@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"user-service/internal/user"
+)
+
+// userColumns — столбцы, которые читаются в model.UserModel
+const userColumns string = "id, username, password_hash, tenant, version, updated_at, created_at"
+
+// sortColumns сопоставляет поля сортировки списка со столбцами таблицы users.
+// В запрос попадают только имена из этого перечня, значения передаются параметрами.
+var sortColumns = map[string]string{
+	user.SortById:        "id",
+	user.SortByUsername:  "username",
+	user.SortByCreatedAt: "created_at",
+}
+
+// likeEscaper экранирует символы шаблона LIKE; обратная косая черта — экранирующий символ по умолчанию
+var likeEscaper *strings.Replacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// queryBuilder собирает условия запроса и нумерует его параметры
+type queryBuilder struct {
+	conditions []string
+	args       []any
+}
+
+// arg добавляет параметр запроса и возвращает ссылку на него
+func (builder *queryBuilder) arg(value any) string {
+	builder.args = append(builder.args, value)
+	return fmt.Sprintf("$%d", len(builder.args))
+}
+
+func (builder *queryBuilder) where() string {
+	if len(builder.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(builder.conditions, " AND ")
+}
+
+// filter добавляет условия отбора. Префикс имени сравнивается через LIKE, чтобы работал
+// индекс text_pattern_ops, подстрока — через ILIKE с триграммным индексом.
+func (builder *queryBuilder) filter(filter user.UserFilter) {
+	if filter.UsernamePrefix != "" {
+		builder.conditions = append(builder.conditions, "username LIKE "+builder.arg(likeEscaper.Replace(filter.UsernamePrefix)+"%"))
+	}
+	if filter.Search != "" {
+		builder.conditions = append(builder.conditions, "username ILIKE "+builder.arg("%"+likeEscaper.Replace(filter.Search)+"%"))
+	}
+	if !filter.CreatedAfter.IsZero() {
+		builder.conditions = append(builder.conditions, "created_at > "+builder.arg(filter.CreatedAfter))
+	}
+}
+
+// keyset добавляет условие, оставляющее записи после key в порядке sort. Если все поля
+// сортируются в одном направлении, используется сравнение строк, которое планировщик
+// превращает в границу просмотра индекса; иначе условие раскрывается по полям:
+// (a > x) OR (a = x AND b > y) OR ...
+func (builder *queryBuilder) keyset(sort []user.SortField, key user.UserKey) {
+	columns := make([]string, 0, len(sort))
+	values := make([]string, 0, len(sort))
+	for _, field := range sort {
+		columns = append(columns, sortColumns[field.Field])
+		values = append(values, builder.arg(keyValue(field.Field, key)))
+	}
+
+	if !slices.ContainsFunc(sort, func(field user.SortField) bool { return field.Desc != sort[0].Desc }) {
+		builder.conditions = append(builder.conditions, fmt.Sprintf("(%s) %s (%s)",
+			strings.Join(columns, ", "), compareOperator(sort[0].Desc), strings.Join(values, ", ")))
+		return
+	}
+
+	alternatives := make([]string, 0, len(sort))
+	for i, field := range sort {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, columns[j]+" = "+values[j])
+		}
+		parts = append(parts, columns[i]+" "+compareOperator(field.Desc)+" "+values[i])
+		alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+	}
+	builder.conditions = append(builder.conditions, "("+strings.Join(alternatives, " OR ")+")")
+}
+
+// checkSort проверяет, что все поля сортировки есть в перечне sortColumns
+func checkSort(sort []user.SortField) error {
+	for _, field := range sort {
+		if _, ok := sortColumns[field.Field]; !ok {
+			return fmt.Errorf("неизвестное поле сортировки %q", field.Field)
+		}
+	}
+	return nil
+}
+
+// orderBy возвращает предложение ORDER BY для порядка sort
+func orderBy(sort []user.SortField) string {
+	items := make([]string, 0, len(sort))
+	for _, field := range sort {
+		item := sortColumns[field.Field]
+		if field.Desc {
+			item += " DESC"
+		}
+		items = append(items, item)
+	}
+	return " ORDER BY " + strings.Join(items, ", ")
+}
+
+// reverseSort меняет направление каждого поля сортировки
+func reverseSort(sort []user.SortField) []user.SortField {
+	reversed := make([]user.SortField, 0, len(sort))
+	for _, field := range sort {
+		reversed = append(reversed, user.SortField{Field: field.Field, Desc: !field.Desc})
+	}
+	return reversed
+}
+
+func compareOperator(desc bool) string {
+	if desc {
+		return "<"
+	}
+	return ">"
+}
+
+func keyValue(field string, key user.UserKey) any {
+	switch field {
+	case user.SortByUsername:
+		return key.Username
+	case user.SortByCreatedAt:
+		return key.CreatedAt
+	default:
+		return key.Id
+	}
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"testing"
+	"time"
+	"user-service/internal/user"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	t.Run("Filter", func(t *testing.T) {
+		createdAfter := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+		var builder queryBuilder
+		builder.filter(user.UserFilter{UsernamePrefix: "adm_", Search: "50%", CreatedAfter: createdAfter})
+		assert.Equal(t, builder.where(), " WHERE username LIKE $1 AND username ILIKE $2 AND created_at > $3")
+		assert.Equal(t, builder.args, []any{`adm\_%`, `%50\%%`, createdAfter})
+	})
+
+	t.Run("Empty filter", func(t *testing.T) {
+		var builder queryBuilder
+		builder.filter(user.UserFilter{})
+		assert.Equal(t, builder.where(), "")
+	})
+
+	t.Run("Keyset with one direction", func(t *testing.T) {
+		sort := []user.SortField{{Field: user.SortByUsername, Desc: true}, {Field: user.SortById, Desc: true}}
+		var builder queryBuilder
+		builder.keyset(sort, user.UserKey{Id: 7, Username: "user7"})
+		assert.Equal(t, builder.where(), " WHERE (username, id) < ($1, $2)")
+		assert.Equal(t, builder.args, []any{"user7", 7})
+		assert.Equal(t, orderBy(sort), " ORDER BY username DESC, id DESC")
+	})
+
+	t.Run("Keyset with mixed directions", func(t *testing.T) {
+		sort := []user.SortField{{Field: user.SortByUsername}, {Field: user.SortById, Desc: true}}
+		var builder queryBuilder
+		builder.keyset(sort, user.UserKey{Id: 7, Username: "user7"})
+		assert.Equal(t, builder.where(), " WHERE ((username > $1) OR (username = $1 AND id < $2))")
+		assert.Equal(t, orderBy(reverseSort(sort)), " ORDER BY username DESC, id")
+	})
+
+	t.Run("Unknown sort field", func(t *testing.T) {
+		assert.NotEqual(t, checkSort([]user.SortField{{Field: "password_hash"}}), nil)
+	})
+}
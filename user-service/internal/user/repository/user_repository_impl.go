@@ -1,120 +1,230 @@
 package repository
 
 import (
-	"fmt"
+	"context"
+	"database/sql"
+	"errors"
 	"log"
+	"slices"
 	"user-service/internal/model"
 	"user-service/internal/user"
 	"user-service/pkg/db"
 )
 
+// defaultRoleName — роль, назначаемая каждому новому пользователю
+const defaultRoleName string = "user"
+
 type userRepositoryImpl struct {
 	db db.DB
 }
 
 // Create implements user.UserRepository.
-func (userRepository *userRepositoryImpl) Create(userModel *model.UserModel) (*model.UserModel, error) {
-	dbConnect, err := userRepository.db.OpenConnect()
-	if err != nil {
-		return nil, err
-	}
-	defer dbConnect.Close()
+func (userRepository *userRepositoryImpl) Create(ctx context.Context, userModel *model.UserModel) (*model.UserModel, error) {
+	ctx, cancel := userRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
 
+	dbConnect := userRepository.db.Querier(ctx)
+
+	// Новый пользователь сразу получает роль по умолчанию в том же запросе
 	var id int
-	dbConnect.QueryRow(
-		"INSERT INTO users (username, password_hash) VALUES ($1, $2) returning id",
+	err := dbConnect.QueryRowContext(ctx,
+		`WITH new_user AS (
+			INSERT INTO users (username, password_hash) VALUES ($1, $2) RETURNING id
+		), default_role AS (
+			INSERT INTO user_roles (user_id, role_id)
+			SELECT new_user.id, roles.id FROM new_user, roles WHERE roles.name = $3
+		)
+		SELECT id FROM new_user`,
 		&userModel.Username,
 		&userModel.Password_Hash,
+		defaultRoleName,
 	).Scan(&id)
+	if err != nil {
+		if _, ok := db.IsUniqueViolation(err); ok {
+			return nil, user.ErrUsernameTaken
+		}
+		return nil, err
+	}
 
-	return userRepository.getOneById(id)
+	return userRepository.getOneById(ctx, id)
 }
 
 // GetAll implements user.UserRepository.
-func (userRepository *userRepositoryImpl) GetAll(offset, limit int) ([]*model.UserModel, error) {
-	dbConnect, err := userRepository.db.OpenConnect()
-	if err != nil {
+func (userRepository *userRepositoryImpl) GetAll(ctx context.Context, query user.UserQuery) ([]*model.UserModel, error) {
+	sort := query.Sort
+	if len(sort) == 0 {
+		sort = []user.SortField{{Field: user.SortById}}
+	}
+	if err := checkSort(sort); err != nil {
 		return nil, err
 	}
-	defer dbConnect.Close()
+	// Страница перед границей выбирается в обратном порядке и затем разворачивается
+	if query.Backward {
+		sort = reverseSort(sort)
+	}
+
+	var builder queryBuilder
+	builder.filter(query.Filter)
+	if query.Key != nil {
+		builder.keyset(sort, *query.Key)
+	}
+	sqlQuery := "SELECT " + userColumns + " FROM users" + builder.where() + orderBy(sort) +
+		" LIMIT " + builder.arg(query.Limit) + " OFFSET " + builder.arg(query.Offset)
 
-	rows, err := dbConnect.Query("SELECT id, username, password_hash FROM users LIMIT $1 OFFSET $2", limit, offset)
+	users, err := userRepository.queryUsers(ctx, "GetAll", sqlQuery, builder.args...)
 	if err != nil {
 		return nil, err
 	}
+	if query.Backward {
+		slices.Reverse(users)
+	}
+	return users, nil
+}
 
-	var users []*model.UserModel
-	for rows.Next() {
-		var foundUser model.UserModel
-		if err := rows.Scan(&foundUser.Id, &foundUser.Username, &foundUser.Password_Hash); err != nil {
-			log.Println("Ошибка сканирования строки из запроса GetAll!")
-			continue
+// Count implements user.UserRepository.
+func (userRepository *userRepositoryImpl) Count(ctx context.Context, filter user.UserFilter, estimate bool) (int, bool, error) {
+	ctx, cancel := userRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := userRepository.db.Querier(ctx)
+
+	if estimate && filter.IsEmpty() {
+		// Оценка берется из статистики планировщика; -1 означает, что таблица еще не анализировалась
+		var reltuples float64
+		if err := dbConnect.QueryRowContext(ctx, "SELECT reltuples FROM pg_class WHERE oid = 'users'::regclass").Scan(&reltuples); err != nil {
+			return 0, false, err
+		}
+		if reltuples >= 0 {
+			return int(reltuples), true, nil
 		}
-		users = append(users, &foundUser)
 	}
 
-	return users, nil
+	var builder queryBuilder
+	builder.filter(filter)
+	var count int
+	if err := dbConnect.QueryRowContext(ctx, "SELECT COUNT(*) FROM users"+builder.where(), builder.args...).Scan(&count); err != nil {
+		return 0, false, err
+	}
+
+	return count, false, nil
 }
 
 // GetOne implements user.UserRepository.
-func (userRepository *userRepositoryImpl) GetOne(id int) (*model.UserModel, error) {
-	return userRepository.getOneById(id)
+func (userRepository *userRepositoryImpl) GetOne(ctx context.Context, id int) (*model.UserModel, error) {
+	return userRepository.getOneById(ctx, id)
 }
 
 // Update implements user.UserRepository.
-func (userRepository *userRepositoryImpl) Update(id int, userModel *model.UserModel) (*model.UserModel, error) {
-	dbConnect, err := userRepository.db.OpenConnect()
-	if err != nil {
-		return nil, err
-	}
-	defer dbConnect.Close()
+func (userRepository *userRepositoryImpl) Update(ctx context.Context, id int, userModel *model.UserModel) (*model.UserModel, error) {
+	ctx, cancel := userRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := userRepository.db.Querier(ctx)
 
-	result, err := dbConnect.Exec("UPDATE users SET username=$1, password_hash=$2 WHERE id=$3",
+	// Пустые значения означают, что поле не изменяется; версия 0 — что версия не проверяется
+	result, err := dbConnect.ExecContext(ctx,
+		`UPDATE users SET
+			username=COALESCE(NULLIF($1, ''), username),
+			password_hash=COALESCE(NULLIF($2, ''), password_hash),
+			version=version+1,
+			updated_at=NOW()
+		WHERE id=$3 AND ($4=0 OR version=$4)`,
 		&userModel.Username,
 		&userModel.Password_Hash,
 		&id,
+		&userModel.Version,
 	)
 	if err != nil {
+		if _, ok := db.IsUniqueViolation(err); ok {
+			return nil, user.ErrUsernameTaken
+		}
 		return nil, err
 	}
 
-	if count, err := result.RowsAffected(); count == 0 && err == nil {
-		return nil, fmt.Errorf("не удалось обновить пользователя с 'id'=%d", id)
+	if count, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if count == 0 {
+		return nil, userRepository.notUpdatedError(ctx, id)
 	}
 
-	return userRepository.getOneById(id)
+	return userRepository.getOneById(ctx, id)
 }
 
-// Delete implements user.UserRepository.
-func (userRepository *userRepositoryImpl) Delete(id int) error {
-	dbConnect, err := userRepository.db.OpenConnect()
+// UpdatePasswordHash implements user.UserRepository.
+func (userRepository *userRepositoryImpl) UpdatePasswordHash(ctx context.Context, id int, passwordHash string) error {
+	ctx, cancel := userRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := userRepository.db.Querier(ctx)
+
+	result, err := dbConnect.ExecContext(ctx, "UPDATE users SET password_hash=$1, version=version+1, updated_at=NOW() WHERE id=$2", passwordHash, id)
 	if err != nil {
 		return err
 	}
-	defer dbConnect.Close()
 
-	result, err := dbConnect.Exec("DELETE FROM users WHERE id=$1", id)
+	if count, err := result.RowsAffected(); err != nil {
+		return err
+	} else if count == 0 {
+		return user.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UpdateTenant implements user.UserRepository.
+func (userRepository *userRepositoryImpl) UpdateTenant(ctx context.Context, id int, tenant string, version int) (*model.UserModel, error) {
+	ctx, cancel := userRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := userRepository.db.Querier(ctx)
+
+	result, err := dbConnect.ExecContext(ctx,
+		"UPDATE users SET tenant=$1, version=version+1, updated_at=NOW() WHERE id=$2 AND ($3=0 OR version=$3)",
+		tenant, id, version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if count, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if count == 0 {
+		return nil, userRepository.notUpdatedError(ctx, id)
+	}
+
+	return userRepository.getOneById(ctx, id)
+}
+
+// Delete implements user.UserRepository.
+func (userRepository *userRepositoryImpl) Delete(ctx context.Context, id int, version int) error {
+	ctx, cancel := userRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := userRepository.db.Querier(ctx)
+
+	result, err := dbConnect.ExecContext(ctx, "DELETE FROM users WHERE id=$1 AND ($2=0 OR version=$2)", id, version)
 	if err != nil {
 		return err
 	}
 
-	if count, err := result.RowsAffected(); count == 0 && err == nil {
-		return fmt.Errorf("не удалось удалить пользователя с 'id'=%d", id)
+	if count, err := result.RowsAffected(); err != nil {
+		return err
+	} else if count == 0 {
+		return userRepository.notUpdatedError(ctx, id)
 	}
 
 	return nil
 }
 
 // ExistById implements user.UserRepository.
-func (userRepository *userRepositoryImpl) ExistById(id int) (bool, error) {
-	dbConnect, err := userRepository.db.OpenConnect()
-	if err != nil {
-		return false, err
-	}
-	defer dbConnect.Close()
+func (userRepository *userRepositoryImpl) ExistById(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := userRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := userRepository.db.Querier(ctx)
 
 	var count int
-	if err = dbConnect.QueryRow("SELECT COUNT(*) FROM users WHERE id=$1", id).Scan(&count); err != nil {
+	if err := dbConnect.QueryRowContext(ctx, "SELECT COUNT(*) FROM users WHERE id=$1", id).Scan(&count); err != nil {
 		return false, err
 	}
 
@@ -122,15 +232,14 @@ func (userRepository *userRepositoryImpl) ExistById(id int) (bool, error) {
 }
 
 // ExistByUsername implements user.UserRepository.
-func (userRepository *userRepositoryImpl) ExistByUsername(username string) (bool, error) {
-	dbConnect, err := userRepository.db.OpenConnect()
-	if err != nil {
-		return false, err
-	}
-	defer dbConnect.Close()
+func (userRepository *userRepositoryImpl) ExistByUsername(ctx context.Context, username string) (bool, error) {
+	ctx, cancel := userRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := userRepository.db.Querier(ctx)
 
 	var count int
-	if err = dbConnect.QueryRow("SELECT COUNT(*) FROM users WHERE username=$1", username).Scan(&count); err != nil {
+	if err := dbConnect.QueryRowContext(ctx, "SELECT COUNT(*) FROM users WHERE username=$1", username).Scan(&count); err != nil {
 		return false, err
 	}
 
@@ -138,41 +247,104 @@ func (userRepository *userRepositoryImpl) ExistByUsername(username string) (bool
 }
 
 // ExistByUsernameAndNotId implements user.UserRepository.
-func (userRepository *userRepositoryImpl) ExistByUsernameAndNotId(username string, id int) (bool, error) {
-	dbConnect, err := userRepository.db.OpenConnect()
-	if err != nil {
-		return false, err
-	}
-	defer dbConnect.Close()
+func (userRepository *userRepositoryImpl) ExistByUsernameAndNotId(ctx context.Context, username string, id int) (bool, error) {
+	ctx, cancel := userRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := userRepository.db.Querier(ctx)
 
 	var count int
-	if err = dbConnect.QueryRow("SELECT COUNT(*) FROM users WHERE username=$1 and id!=$2", username, id).Scan(&count); err != nil {
+	if err := dbConnect.QueryRowContext(ctx, "SELECT COUNT(*) FROM users WHERE username=$1 and id!=$2", username, id).Scan(&count); err != nil {
 		return false, err
 	}
 
 	return count > 0, nil
 }
 
-func (userRepository *userRepositoryImpl) getOneById(id int) (*model.UserModel, error) {
-	dbConnect, err := userRepository.db.OpenConnect()
+// GetByUsername implements user.UserRepository.
+func (userRepository *userRepositoryImpl) GetByUsername(ctx context.Context, username string) (*model.UserModel, error) {
+	ctx, cancel := userRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := userRepository.db.Querier(ctx)
+
+	row := dbConnect.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE username=$1", username)
+	if err := row.Err(); err != nil {
+		return nil, err
+	}
+
+	var foundUser model.UserModel
+	err := row.Scan(&foundUser.Id, &foundUser.Username, &foundUser.Password_Hash, &foundUser.Tenant, &foundUser.Version, &foundUser.UpdatedAt, &foundUser.CreatedAt)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, user.ErrUserNotFound
+		}
 		return nil, err
 	}
-	defer dbConnect.Close()
+	return &foundUser, nil
+}
+
+// notUpdatedError объясняет, почему запрос с проверкой версии не затронул ни одной строки:
+// пользователя нет или его версия уже изменилась
+func (userRepository *userRepositoryImpl) notUpdatedError(ctx context.Context, id int) error {
+	isExist, err := userRepository.ExistById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !isExist {
+		return user.ErrUserNotFound
+	}
+	return user.ErrVersionMismatch
+}
 
-	row := dbConnect.QueryRow("SELECT id, username, password_hash FROM users WHERE id=$1", id)
+func (userRepository *userRepositoryImpl) getOneById(ctx context.Context, id int) (*model.UserModel, error) {
+	ctx, cancel := userRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := userRepository.db.Querier(ctx)
+
+	row := dbConnect.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE id=$1", id)
 	if err := row.Err(); err != nil {
 		return nil, err
 	}
 
 	var foundUser model.UserModel
-	err = row.Scan(&foundUser.Id, &foundUser.Username, &foundUser.Password_Hash)
+	err := row.Scan(&foundUser.Id, &foundUser.Username, &foundUser.Password_Hash, &foundUser.Tenant, &foundUser.Version, &foundUser.UpdatedAt, &foundUser.CreatedAt)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, user.ErrUserNotFound
+		}
 		return nil, err
 	}
 	return &foundUser, nil
 }
 
+// queryUsers выполняет запрос списка пользователей. Строки, которые не удалось прочитать, пропускаются.
+func (userRepository *userRepositoryImpl) queryUsers(ctx context.Context, queryName string, query string, args ...any) ([]*model.UserModel, error) {
+	ctx, cancel := userRepository.db.WithQueryTimeout(ctx)
+	defer cancel()
+
+	dbConnect := userRepository.db.Querier(ctx)
+
+	rows, err := dbConnect.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*model.UserModel
+	for rows.Next() {
+		var foundUser model.UserModel
+		if err := rows.Scan(&foundUser.Id, &foundUser.Username, &foundUser.Password_Hash, &foundUser.Tenant, &foundUser.Version, &foundUser.UpdatedAt, &foundUser.CreatedAt); err != nil {
+			log.Printf("Ошибка сканирования строки из запроса %s!\n", queryName)
+			continue
+		}
+		users = append(users, &foundUser)
+	}
+
+	return users, rows.Err()
+}
+
 func NewUserRepository(db db.DB) user.UserRepository {
 	return &userRepositoryImpl{
 		db: db,
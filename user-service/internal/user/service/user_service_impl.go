@@ -1,73 +1,288 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+	"unicode/utf8"
 	"user-service/internal/model"
 	"user-service/internal/user"
 	"user-service/internal/user/prometheus"
+	"user-service/pkg/cursor"
+	"user-service/pkg/db"
 )
 
 type userServiceImpl struct {
-	userRepository user.UserRepository
-	userPrometheus user.UserPrometheus
+	userRepository         user.UserRepository
+	unitOfWork             db.UnitOfWork
+	userPrometheus         user.UserPrometheus
+	countEstimateThreshold int
 }
 
 // Create implements user.UserService.
-func (userService *userServiceImpl) Create(userModel *model.UserModel) (*model.UserModel, error) {
-	userModel, err := userService.userRepository.Create(userModel)
-	if err == nil {
-		userService.userPrometheus.New()
+func (userService *userServiceImpl) Create(ctx context.Context, userModel *model.UserModel) (*model.UserModel, error) {
+	var createdUser *model.UserModel
+	err := userService.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		isExistUsername, err := userService.userRepository.ExistByUsername(ctx, userModel.Username)
+		if err != nil {
+			return err
+		}
+		if isExistUsername {
+			return user.ErrUsernameTaken
+		}
+
+		createdUser, err = userService.userRepository.Create(ctx, userModel)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userService.userPrometheus.New()
+	return createdUser, nil
+}
+
+// Ограничения фильтров списка. Подстрока короче трех символов не использует триграммный индекс.
+const (
+	maxUsernameLength int = 32
+	minSearchLength   int = 3
+)
+
+// Ограничения страницы списка. Номер страницы ограничен, чтобы смещение (page-1)*limit
+// не переполнялось и не заставляло базу пропускать миллионы строк: дальше листают курсорами.
+const (
+	maxPageLimit int = 100
+	maxPage      int = 10000
+)
+
+// cursorKey — значения полей сортировки записи на границе страницы. Поля, не участвующие
+// в сортировке, не заполняются.
+type cursorKey struct {
+	Id        int        `json:"i"`
+	Username  string     `json:"u,omitempty"`
+	CreatedAt *time.Time `json:"c,omitempty"`
+}
+
+func newCursorKey(sort []user.SortField, userModel *model.UserModel) *cursorKey {
+	key := &cursorKey{Id: userModel.Id}
+	for _, field := range sort {
+		switch field.Field {
+		case user.SortByUsername:
+			key.Username = userModel.Username
+		case user.SortByCreatedAt:
+			key.CreatedAt = &userModel.CreatedAt
+		}
+	}
+	return key
+}
+
+func (key *cursorKey) userKey() *user.UserKey {
+	if key == nil {
+		return nil
 	}
-	return userModel, err
+	userKey := &user.UserKey{Id: key.Id, Username: key.Username}
+	if key.CreatedAt != nil {
+		userKey.CreatedAt = *key.CreatedAt
+	}
+	return userKey
+}
+
+// pageCursor — содержимое курсора страницы: ключ записи, после которой начинается следующая
+// страница, ключ записи, перед которой заканчивается предыдущая, или признак последней страницы.
+// Sort — порядок сортировки, для которого действителен ключ.
+type pageCursor struct {
+	After  *cursorKey `json:"a,omitempty"`
+	Before *cursorKey `json:"b,omitempty"`
+	Last   bool       `json:"l,omitempty"`
+	Sort   string     `json:"s"`
+}
+
+// valid проверяет, что курсор задает не больше одной границы и выдан для порядка sort
+func (position pageCursor) valid(sort string) bool {
+	bounds := 0
+	for _, isSet := range []bool{position.After != nil, position.Before != nil, position.Last} {
+		if isSet {
+			bounds++
+		}
+	}
+	return bounds <= 1 && position.Sort == sort
 }
 
 // GetAll implements user.UserService.
-func (userService *userServiceImpl) GetAll(page int, limit int) ([]*model.UserModel, error) {
-	var offset int
-	if page == 0 {
-		offset = 0
-	} else {
-		offset = limit * page
-	}
-	return userService.userRepository.GetAll(offset, limit)
+func (userService *userServiceImpl) GetAll(ctx context.Context, pageRequest user.PageRequest) (*user.UserPage, error) {
+	var fields []user.FieldError
+	if pageRequest.Page < 0 {
+		fields = append(fields, user.FieldError{Field: "page", Rule: "gte", Param: "1"})
+	} else if pageRequest.Page > maxPage {
+		fields = append(fields, user.FieldError{Field: "page", Rule: "lte", Param: strconv.Itoa(maxPage)})
+	}
+	if pageRequest.Limit < 1 {
+		fields = append(fields, user.FieldError{Field: "limit", Rule: "gte", Param: "1"})
+	} else if pageRequest.Limit > maxPageLimit {
+		fields = append(fields, user.FieldError{Field: "limit", Rule: "lte", Param: strconv.Itoa(maxPageLimit)})
+	}
+	if utf8.RuneCountInString(pageRequest.Filter.UsernamePrefix) > maxUsernameLength {
+		fields = append(fields, user.FieldError{Field: "username_prefix", Rule: "max", Param: strconv.Itoa(maxUsernameLength)})
+	}
+	if length := utf8.RuneCountInString(pageRequest.Filter.Search); length > maxUsernameLength {
+		fields = append(fields, user.FieldError{Field: "q", Rule: "max", Param: strconv.Itoa(maxUsernameLength)})
+	} else if length > 0 && length < minSearchLength {
+		fields = append(fields, user.FieldError{Field: "q", Rule: "min", Param: strconv.Itoa(minSearchLength)})
+	}
+	sort, err := user.ParseSort(pageRequest.Sort)
+	if err != nil {
+		var sortErr *user.ValidationError
+		if !errors.As(err, &sortErr) {
+			return nil, err
+		}
+		fields = append(fields, sortErr.Fields...)
+	}
+	// Курсор проверяется, только если известен порядок, для которого он должен быть выдан
+	var position pageCursor
+	if pageRequest.Cursor != "" && err == nil {
+		if pageRequest.Page != 0 {
+			fields = append(fields, user.FieldError{Field: "cursor", Rule: "excluded_with", Param: "page"})
+		} else if err := cursor.Decode(pageRequest.Cursor, &position); err != nil || !position.valid(user.FormatSort(sort)) {
+			fields = append(fields, user.FieldError{Field: "cursor", Rule: "cursor"})
+		}
+	}
+	if len(fields) > 0 {
+		return nil, &user.ValidationError{Fields: fields}
+	}
+
+	// Запрашивается на одну запись больше, чтобы узнать, есть ли страница за текущей
+	limit := pageRequest.Limit
+	query := user.UserQuery{Filter: pageRequest.Filter, Sort: sort, Limit: limit + 1}
+	var users []*model.UserModel
+	var hasNext, hasPrev bool
+	switch {
+	case position.Before != nil || position.Last:
+		// У последней страницы нет границы: страница отсчитывается от конца списка
+		query.Key = position.Before.userKey()
+		query.Backward = true
+		users, err = userService.userRepository.GetAll(ctx, query)
+		if hasPrev = len(users) > limit; hasPrev {
+			users = users[1:]
+		}
+		hasNext = !position.Last
+	case pageRequest.Page > 1:
+		// Номер страницы поддерживается для совместимости; дальше клиент может идти по курсорам
+		query.Offset = (pageRequest.Page - 1) * limit
+		users, err = userService.userRepository.GetAll(ctx, query)
+		if hasNext = len(users) > limit; hasNext {
+			users = users[:limit]
+		}
+		hasPrev = true
+	default:
+		query.Key = position.After.userKey()
+		users, err = userService.userRepository.GetAll(ctx, query)
+		if hasNext = len(users) > limit; hasNext {
+			users = users[:limit]
+		}
+		hasPrev = position.After != nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	userPage := &user.UserPage{Users: users}
+	if pageRequest.WithTotal {
+		if userPage.Total, userPage.TotalEstimated, err = userService.count(ctx, pageRequest.Filter); err != nil {
+			return nil, err
+		}
+	}
+
+	// У пустой страницы нет записей, от которых можно отсчитать соседние страницы
+	if len(users) == 0 {
+		return userPage, nil
+	}
+	sortValue := user.FormatSort(sort)
+	if userPage.Last, err = cursor.Encode(pageCursor{Last: true, Sort: sortValue}); err != nil {
+		return nil, err
+	}
+	if hasNext {
+		if userPage.Next, err = cursor.Encode(pageCursor{After: newCursorKey(sort, users[len(users)-1]), Sort: sortValue}); err != nil {
+			return nil, err
+		}
+	}
+	if hasPrev {
+		if userPage.Prev, err = cursor.Encode(pageCursor{Before: newCursorKey(sort, users[0]), Sort: sortValue}); err != nil {
+			return nil, err
+		}
+	}
+	return userPage, nil
+}
+
+// count возвращает общее число пользователей, подходящих под filter. Если по статистике
+// планировщика пользователей больше countEstimateThreshold, точный подсчет всей таблицы
+// пропускается и возвращается оценка. С фильтром оценки нет, и число всегда подсчитывается точно.
+func (userService *userServiceImpl) count(ctx context.Context, filter user.UserFilter) (int, bool, error) {
+	if userService.countEstimateThreshold > 0 && filter.IsEmpty() {
+		total, estimated, err := userService.userRepository.Count(ctx, filter, true)
+		if err != nil {
+			return 0, false, err
+		}
+		// Без статистики репозиторий уже подсчитал точно, и повторно таблица не просматривается
+		if !estimated || total > userService.countEstimateThreshold {
+			return total, estimated, nil
+		}
+	}
+
+	total, _, err := userService.userRepository.Count(ctx, filter, false)
+	return total, false, err
 }
 
 // GetOne implements user.UserService.
-func (userService *userServiceImpl) GetOne(id int) (*model.UserModel, error) {
-	return userService.userRepository.GetOne(id)
+func (userService *userServiceImpl) GetOne(ctx context.Context, id int) (*model.UserModel, error) {
+	return userService.userRepository.GetOne(ctx, id)
 }
 
 // Update implements user.UserService.
-func (userService *userServiceImpl) Update(id int, userModel *model.UserModel) (*model.UserModel, error) {
-	return userService.userRepository.Update(id, userModel)
+func (userService *userServiceImpl) Update(ctx context.Context, id int, userModel *model.UserModel) (*model.UserModel, error) {
+	var updatedUser *model.UserModel
+	err := userService.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if userModel.Username != "" {
+			isExistUsername, err := userService.userRepository.ExistByUsernameAndNotId(ctx, userModel.Username, id)
+			if err != nil {
+				return err
+			}
+			if isExistUsername {
+				return user.ErrUsernameTaken
+			}
+		}
+
+		var err error
+		updatedUser, err = userService.userRepository.Update(ctx, id, userModel)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updatedUser, nil
+}
+
+// SetTenant implements user.UserService.
+func (userService *userServiceImpl) SetTenant(ctx context.Context, id int, tenant string, version int) (*model.UserModel, error) {
+	return userService.userRepository.UpdateTenant(ctx, id, tenant, version)
 }
 
 // Delete implements user.UserService.
-func (userService *userServiceImpl) Delete(id int) error {
-	err := userService.userRepository.Delete(id)
+func (userService *userServiceImpl) Delete(ctx context.Context, id int, version int) error {
+	err := userService.userRepository.Delete(ctx, id, version)
 	if err == nil {
 		userService.userPrometheus.Delete()
 	}
 	return err
 }
 
-// ExistById implements user.UserService.
-func (userService *userServiceImpl) ExistById(id int) (bool, error) {
-	return userService.userRepository.ExistById(id)
-}
-
-// ExistByUsername implements user.UserService.
-func (userService *userServiceImpl) ExistByUsername(username string) (bool, error) {
-	return userService.userRepository.ExistByUsername(username)
-}
-
-// ExistByUsernameAndNotId implements user.UserService.
-func (userService *userServiceImpl) ExistByUsernameAndNotId(username string, id int) (bool, error) {
-	return userService.userRepository.ExistByUsernameAndNotId(username, id)
-}
-
-func NewUserService(userRepository user.UserRepository) user.UserService {
+// NewUserService создает сервис пользователей. countEstimateThreshold — число пользователей,
+// начиная с которого общее число в списке оценивается, а не подсчитывается точно (0 — всегда точно).
+func NewUserService(userRepository user.UserRepository, unitOfWork db.UnitOfWork, countEstimateThreshold int) user.UserService {
 	return &userServiceImpl{
-		userRepository: userRepository,
-		userPrometheus: prometheus.NewUserPrometheus(),
+		userRepository:         userRepository,
+		unitOfWork:             unitOfWork,
+		userPrometheus:         prometheus.NewUserPrometheus(),
+		countEstimateThreshold: countEstimateThreshold,
 	}
 }
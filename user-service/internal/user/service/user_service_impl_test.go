@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"user-service/internal/user"
+
+	"github.com/go-playground/assert/v2"
+)
+
+// validationFields возвращает поля ошибки проверки или nil, если err не ValidationError
+func validationFields(err error) []user.FieldError {
+	var validationErr *user.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil
+	}
+	return validationErr.Fields
+}
+
+func TestGetAllPageBounds(t *testing.T) {
+	// Некорректный запрос отклоняется до обращения к репозиторию
+	userService := &userServiceImpl{}
+
+	t.Run("Limit too large", func(t *testing.T) {
+		_, err := userService.GetAll(context.Background(), user.PageRequest{Limit: maxPageLimit + 1})
+		assert.Equal(t, validationFields(err), []user.FieldError{{Field: "limit", Rule: "lte", Param: "100"}})
+	})
+
+	t.Run("Limit overflow", func(t *testing.T) {
+		_, err := userService.GetAll(context.Background(), user.PageRequest{Limit: math.MaxInt})
+		assert.Equal(t, validationFields(err), []user.FieldError{{Field: "limit", Rule: "lte", Param: "100"}})
+	})
+
+	t.Run("Page too large", func(t *testing.T) {
+		_, err := userService.GetAll(context.Background(), user.PageRequest{Page: math.MaxInt, Limit: maxPageLimit})
+		assert.Equal(t, validationFields(err), []user.FieldError{{Field: "page", Rule: "lte", Param: "10000"}})
+	})
+}
+
+// countRepository подсчитывает вызовы Count; остальные методы репозитория в тестах не используются
+type countRepository struct {
+	user.UserRepository
+	estimate  int
+	exact     int
+	estimated bool
+	calls     int
+}
+
+func (repository *countRepository) Count(ctx context.Context, filter user.UserFilter, estimate bool) (int, bool, error) {
+	repository.calls++
+	if estimate && repository.estimated {
+		return repository.estimate, true, nil
+	}
+	return repository.exact, false, nil
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		repo      countRepository
+		total     int
+		estimated bool
+		calls     int
+	}{
+		{name: "Estimate above threshold", repo: countRepository{estimate: 5000, exact: 4990, estimated: true}, total: 5000, estimated: true, calls: 1},
+		{name: "Estimate below threshold", repo: countRepository{estimate: 50, exact: 42, estimated: true}, total: 42, estimated: false, calls: 2},
+		{name: "No statistics", repo: countRepository{exact: 42}, total: 42, estimated: false, calls: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repository := test.repo
+			userService := &userServiceImpl{userRepository: &repository, countEstimateThreshold: 1000}
+			total, estimated, err := userService.count(context.Background(), user.UserFilter{})
+			assert.Equal(t, err, nil)
+			assert.Equal(t, total, test.total)
+			assert.Equal(t, estimated, test.estimated)
+			assert.Equal(t, repository.calls, test.calls)
+		})
+	}
+}
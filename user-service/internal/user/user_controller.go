@@ -1,34 +1,124 @@
 package user
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
+	"user-service/internal/dto"
 	"user-service/internal/middleware"
+	"user-service/internal/policy"
 
 	"github.com/gorilla/mux"
 )
 
+// Действия ABAC над пользователем, которые проверяются правилами config/policies.yml
+const (
+	actionRead           string = "users:read"
+	actionUpdate         string = "users:update"
+	actionUpdatePassword string = "users:update_password"
+	actionDelete         string = "users:delete"
+)
+
 type UserController interface {
 	Create() http.Handler
 	GetAll() http.Handler
 	GetOne() http.Handler
 	Update() http.Handler
+	Patch() http.Handler
 	Delete() http.Handler
+	SetTenant() http.Handler
 }
 
-func SetupUserRoutes(router *mux.Router, userController UserController) *mux.Router {
+// SetupUserRoutes регистрирует маршруты пользователей. Доступ к чужой записи решают
+// правила ABAC из policyService: они учитывают владельца, роли, права и организацию.
+// Организацию, от которой зависят правила, назначает только администратор.
+func SetupUserRoutes(router *mux.Router, userController UserController, policyService policy.PolicyService) *mux.Router {
 
 	postRoutes := router.Methods(http.MethodPost).Subrouter()
-	postRoutes.Handle("/users", userController.Create())
+	postRoutes.Handle("/users", middleware.Authorize(middleware.RequirePermission("users:write"), userController.Create()))
 
 	getRoutes := router.Methods(http.MethodGet).Subrouter()
-	getRoutes.Handle("/users/all", userController.GetAll())
-	getRoutes.Handle("/users/{id:[0-9]+}", userController.GetOne())
+	getRoutes.Handle("/users/all", middleware.Authorize(middleware.RequirePermission("users:read"), userController.GetAll()))
+	getRoutes.Handle("/users/{id:[0-9]+}", middleware.Authorize(policy.Require(policyService, actionRead, "id"), userController.GetOne()))
+	getRoutes.Handle("/users/me", middleware.Authorize(middleware.Authenticated(), middleware.SubjectToRouteParam("id", userController.GetOne())))
 
+	// PUT всегда задает пароль, поэтому требует права на его изменение
 	putRoutes := router.Methods(http.MethodPut).Subrouter()
-	putRoutes.Handle("/users/{id:[0-9]+}", userController.Update())
+	putRoutes.Handle("/users/{id:[0-9]+}", middleware.Authorize(policy.Require(policyService, actionUpdatePassword, "id"), userController.Update()))
+	putRoutes.Handle("/users/me", middleware.Authorize(middleware.Authenticated(), middleware.SubjectToRouteParam("id", userController.Update())))
+	putRoutes.Handle("/users/{id:[0-9]+}/tenant", middleware.Authorize(middleware.RequireRole("admin"), userController.SetTenant()))
+
+	patchRoutes := router.Methods(http.MethodPatch).Subrouter()
+	patchRoutes.Handle("/users/{id:[0-9]+}", middleware.Authorize(policy.RequireAction(policyService, patchAction, "id"), userController.Patch()))
+	patchRoutes.Handle("/users/me", middleware.Authorize(middleware.Authenticated(), middleware.SubjectToRouteParam("id", userController.Patch())))
 
 	deleteRoutes := router.Methods(http.MethodDelete).Subrouter()
-	deleteRoutes.Handle("/users/{id:[0-9]+}", middleware.IsPermitionDeleteMiddleware(userController.Delete()))
+	deleteRoutes.Handle("/users/{id:[0-9]+}", middleware.Authorize(policy.Require(policyService, actionDelete, "id"), userController.Delete()))
+	deleteRoutes.Handle("/users/me", middleware.Authorize(middleware.Authenticated(), middleware.SubjectToRouteParam("id", userController.Delete())))
 
 	return router
 }
+
+// patchAction выбирает действие для PATCH: users:update_password, если документ изменений
+// может задать пароль, иначе users:update. Тело запроса восстанавливается для обработчика.
+// Документ, который не удалось разобрать, считается изменяющим пароль: обработчик все равно его отклонит.
+func patchAction(request *http.Request) string {
+	data, err := io.ReadAll(request.Body)
+	if err != nil {
+		return actionUpdatePassword
+	}
+	request.Body = io.NopCloser(bytes.NewReader(data))
+
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return actionUpdatePassword
+	}
+	switch typedDocument := document.(type) {
+	case map[string]any:
+		// JSON Merge Patch меняет только перечисленные поля
+		for key := range typedDocument {
+			if setsPassword(key) {
+				return actionUpdatePassword
+			}
+		}
+		return actionUpdate
+	case []any:
+		// JSON Patch меняет значение по пути path; пустой путь заменяет весь документ
+		for _, item := range typedDocument {
+			operation, ok := item.(map[string]any)
+			if !ok {
+				return actionUpdatePassword
+			}
+			path, _ := operation["path"].(string)
+			if path == "" || setsPassword(rootMember(path)) {
+				return actionUpdatePassword
+			}
+		}
+		return actionUpdate
+	default:
+		return actionUpdatePassword
+	}
+}
+
+// setsPassword проверяет, попадет ли член документа key в поле пароля. Обработчик разбирает
+// документ через encoding/json, который сопоставляет имена без учета регистра, поэтому
+// проверка выполняется тем же разбором, а не сравнением строк.
+func setsPassword(key string) bool {
+	data, err := json.Marshal(map[string]string{key: ""})
+	if err != nil {
+		return true
+	}
+	var patchRequest dto.UserPatchRequest
+	if err := json.Unmarshal(data, &patchRequest); err != nil {
+		return true
+	}
+	return patchRequest.Password != nil
+}
+
+// rootMember возвращает имя члена верхнего уровня, на который указывает JSON Pointer path (RFC 6901)
+func rootMember(path string) string {
+	member, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(member)
+}
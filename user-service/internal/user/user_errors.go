@@ -0,0 +1,43 @@
+package user
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrUserNotFound    error = errors.New("пользователь не найден")
+	ErrUsernameTaken   error = errors.New("пользователь с заданным именем уже существует")
+	ErrForbidden       error = errors.New("операция над пользователем запрещена")
+	ErrValidation      error = errors.New("некорректные данные пользователя")
+	ErrVersionMismatch error = errors.New("пользователь изменен другим запросом")
+)
+
+// FieldError описывает нарушенное правило проверки одного поля запроса
+type FieldError struct {
+	Field string // Имя поля в запросе
+	Rule  string // Нарушенное правило: required, min, max
+	Param string // Параметр правила, например минимальная длина
+}
+
+// ValidationError возвращается, если данные пользователя не прошли проверку.
+// Совпадает с ErrValidation при сравнении через errors.Is.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (err *ValidationError) Error() string {
+	fields := make([]string, 0, len(err.Fields))
+	for _, field := range err.Fields {
+		rule := field.Rule
+		if field.Param != "" {
+			rule += "=" + field.Param
+		}
+		fields = append(fields, field.Field+": "+rule)
+	}
+	return ErrValidation.Error() + " (" + strings.Join(fields, ", ") + ")"
+}
+
+func (err *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
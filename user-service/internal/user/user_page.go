@@ -0,0 +1,29 @@
+package user
+
+import "user-service/internal/model"
+
+// PageRequest выбирает страницу списка пользователей, отобранных по Filter и упорядоченных
+// по Sort (формат ParseSort, по умолчанию по id). Страница задается курсором Cursor, полученным
+// вместе с соседней страницей, или, для совместимости, номером Page с единицы. Если не задано
+// ни то, ни другое, возвращается первая страница. WithTotal запрашивает общее число отобранных
+// пользователей, которое требует отдельного запроса.
+type PageRequest struct {
+	Filter    UserFilter
+	Sort      string
+	Cursor    string
+	Page      int
+	Limit     int
+	WithTotal bool
+}
+
+// UserPage — страница списка пользователей. Next и Prev — курсоры следующей и предыдущей
+// страниц, Last — курсор последней страницы; пустой курсор означает, что такой страницы нет.
+// Total заполняется по запросу WithTotal; TotalEstimated означает, что это оценка, а не точное число.
+type UserPage struct {
+	Users          []*model.UserModel
+	Next           string
+	Prev           string
+	Last           string
+	Total          int
+	TotalEstimated bool
+}
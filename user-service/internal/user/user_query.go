@@ -0,0 +1,103 @@
+package user
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Поля, по которым можно сортировать список пользователей
+const (
+	SortById        string = "id"
+	SortByUsername  string = "username"
+	SortByCreatedAt string = "created_at"
+)
+
+// SortFields перечисляет поля, по которым можно сортировать список пользователей
+var SortFields = []string{SortById, SortByUsername, SortByCreatedAt}
+
+// SortField задает поле сортировки списка и направление
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// UserFilter — условия отбора пользователей списка. Пустое условие не применяется.
+type UserFilter struct {
+	UsernamePrefix string    // Имя начинается с заданной строки
+	Search         string    // Имя содержит заданную строку без учета регистра
+	CreatedAfter   time.Time // Пользователь создан позже заданного времени
+}
+
+// IsEmpty возвращает true, если фильтр не задает ни одного условия
+func (filter UserFilter) IsEmpty() bool {
+	return filter.UsernamePrefix == "" && filter.Search == "" && filter.CreatedAfter.IsZero()
+}
+
+// UserKey — значения полей сортировки записи, на которой проходит граница страницы
+type UserKey struct {
+	Id        int
+	Username  string
+	CreatedAt time.Time
+}
+
+// UserQuery — запрос страницы списка пользователей к UserRepository.
+// Sort должен заканчиваться полем id, чтобы порядок был однозначным.
+// Key — граница страницы, не входящая в нее: страница начинается после Key или, если Backward,
+// заканчивается перед Key. Без Key страница отсчитывается от начала списка или, если Backward, от конца.
+// Записи страницы всегда возвращаются в порядке Sort.
+type UserQuery struct {
+	Filter   UserFilter
+	Sort     []SortField
+	Key      *UserKey
+	Backward bool
+	Offset   int
+	Limit    int
+}
+
+// ParseSort разбирает порядок сортировки вида "username,-id": поля через запятую,
+// минус перед полем означает убывание. Если порядок не заканчивается полем id,
+// оно добавляется по возрастанию. Неизвестные и повторяющиеся поля приводят к ValidationError.
+func ParseSort(value string) ([]SortField, error) {
+	var sort []SortField
+	seen := make(map[string]bool)
+	if value != "" {
+		for _, item := range strings.Split(value, ",") {
+			field := SortField{Field: strings.TrimSpace(item)}
+			if strings.HasPrefix(field.Field, "-") {
+				field = SortField{Field: field.Field[1:], Desc: true}
+			}
+			if !slices.Contains(SortFields, field.Field) {
+				return nil, &ValidationError{Fields: []FieldError{{Field: "sort", Rule: "oneof", Param: strings.Join(SortFields, " ")}}}
+			}
+			if seen[field.Field] {
+				return nil, &ValidationError{Fields: []FieldError{{Field: "sort", Rule: "unique"}}}
+			}
+			seen[field.Field] = true
+			sort = append(sort, field)
+		}
+	}
+	if !seen[SortById] {
+		sort = append(sort, SortField{Field: SortById})
+	}
+	// Поля после id не влияют на порядок, поскольку id уникален
+	for i, field := range sort {
+		if field.Field == SortById {
+			return sort[:i+1], nil
+		}
+	}
+	return sort, nil
+}
+
+// FormatSort записывает порядок сортировки в виде, который принимает ParseSort
+func FormatSort(sort []SortField) string {
+	items := make([]string, 0, len(sort))
+	for _, field := range sort {
+		if field.Desc {
+			items = append(items, "-"+field.Field)
+		} else {
+			items = append(items, field.Field)
+		}
+	}
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,45 @@
+package user
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestParseSort(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		sort, err := ParseSort("")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, sort, []SortField{{Field: SortById}})
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		sort, err := ParseSort("username,-id")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, sort, []SortField{{Field: SortByUsername}, {Field: SortById, Desc: true}})
+		assert.Equal(t, FormatSort(sort), "username,-id")
+	})
+
+	t.Run("Id appended", func(t *testing.T) {
+		sort, err := ParseSort("-created_at")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, sort, []SortField{{Field: SortByCreatedAt, Desc: true}, {Field: SortById}})
+	})
+
+	t.Run("Fields after id dropped", func(t *testing.T) {
+		sort, err := ParseSort("id,username")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, sort, []SortField{{Field: SortById}})
+	})
+
+	t.Run("Unknown field", func(t *testing.T) {
+		_, err := ParseSort("password_hash")
+		assert.Equal(t, errors.Is(err, ErrValidation), true)
+	})
+
+	t.Run("Duplicate field", func(t *testing.T) {
+		_, err := ParseSort("username,-username")
+		assert.Equal(t, errors.Is(err, ErrValidation), true)
+	})
+}
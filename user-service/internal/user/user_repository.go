@@ -1,14 +1,38 @@
 package user
 
-import "user-service/internal/model"
+import (
+	"context"
+	"user-service/internal/model"
+)
 
 type UserRepository interface {
-	Create(userModel *model.UserModel) (*model.UserModel, error)
-	GetAll(offset, limit int) ([]*model.UserModel, error)
-	GetOne(id int) (*model.UserModel, error)
-	Update(id int, userModel *model.UserModel) (*model.UserModel, error)
-	Delete(id int) error
-	ExistById(id int) (bool, error)
-	ExistByUsername(username string) (bool, error)
-	ExistByUsernameAndNotId(username string, id int) (bool, error)
+	// Create создает пользователя. Если имя занято, возвращается ErrUsernameTaken.
+	Create(ctx context.Context, userModel *model.UserModel) (*model.UserModel, error)
+	// GetAll возвращает страницу списка пользователей, описанную query
+	GetAll(ctx context.Context, query UserQuery) ([]*model.UserModel, error)
+	// Count возвращает число пользователей, подходящих под filter. Если estimate и фильтр пуст,
+	// число берется из статистики планировщика без просмотра таблицы и может быть неточным;
+	// без статистики или с фильтром выполняется точный подсчет. Второе значение сообщает, что число — оценка.
+	Count(ctx context.Context, filter UserFilter, estimate bool) (int, bool, error)
+	// GetOne возвращает пользователя или ErrUserNotFound
+	GetOne(ctx context.Context, id int) (*model.UserModel, error)
+	// GetByUsername возвращает пользователя или ErrUserNotFound
+	GetByUsername(ctx context.Context, username string) (*model.UserModel, error)
+	// Update обновляет пользователя и увеличивает его версию. Пустые поля userModel остаются без изменений.
+	// Если userModel.Version не 0, запись обновляется, только если ее версия совпадает, иначе
+	// возвращается ErrVersionMismatch. Если пользователь не найден, возвращается ErrUserNotFound,
+	// если имя занято — ErrUsernameTaken.
+	Update(ctx context.Context, id int, userModel *model.UserModel) (*model.UserModel, error)
+	// UpdatePasswordHash заменяет хеш пароля и увеличивает версию или возвращает ErrUserNotFound
+	UpdatePasswordHash(ctx context.Context, id int, passwordHash string) error
+	// UpdateTenant назначает пользователю организацию и увеличивает версию. Если version не 0,
+	// запись обновляется, только если ее версия совпадает, иначе возвращается ErrVersionMismatch.
+	// Если пользователь не найден, возвращается ErrUserNotFound.
+	UpdateTenant(ctx context.Context, id int, tenant string, version int) (*model.UserModel, error)
+	// Delete удаляет пользователя или возвращает ErrUserNotFound. Если version не 0,
+	// пользователь удаляется, только если его версия совпадает, иначе возвращается ErrVersionMismatch.
+	Delete(ctx context.Context, id int, version int) error
+	ExistById(ctx context.Context, id int) (bool, error)
+	ExistByUsername(ctx context.Context, username string) (bool, error)
+	ExistByUsernameAndNotId(ctx context.Context, username string, id int) (bool, error)
 }
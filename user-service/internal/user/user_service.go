@@ -1,14 +1,30 @@
 package user
 
-import "user-service/internal/model"
+import (
+	"context"
+	"user-service/internal/model"
+)
 
 type UserService interface {
-	Create(userModel *model.UserModel) (*model.UserModel, error)
-	GetAll(page, limit int) ([]*model.UserModel, error)
-	GetOne(id int) (*model.UserModel, error)
-	Update(id int, userModel *model.UserModel) (*model.UserModel, error)
-	Delete(id int) error
-	ExistById(id int) (bool, error)
-	ExistByUsername(username string) (bool, error)
-	ExistByUsernameAndNotId(username string, id int) (bool, error)
+	// Create создает пользователя, проверяя уникальность имени в той же транзакции.
+	// Если имя занято, возвращается ErrUsernameTaken.
+	Create(ctx context.Context, userModel *model.UserModel) (*model.UserModel, error)
+	// GetAll возвращает страницу списка пользователей, выбранную по ключу id, вместе с курсорами
+	// соседних страниц. Некорректный запрос, в том числе испорченный курсор, приводит к ValidationError.
+	GetAll(ctx context.Context, pageRequest PageRequest) (*UserPage, error)
+	// GetOne возвращает пользователя или ErrUserNotFound
+	GetOne(ctx context.Context, id int) (*model.UserModel, error)
+	// Update обновляет пользователя, проверяя уникальность имени в той же транзакции.
+	// Пустые поля userModel остаются без изменений, поэтому Update подходит и для частичного обновления.
+	// Если userModel.Version не 0 и не совпадает с версией пользователя, возвращается ErrVersionMismatch.
+	// Если пользователь не найден, возвращается ErrUserNotFound, если имя занято
+	// другим пользователем — ErrUsernameTaken.
+	Update(ctx context.Context, id int, userModel *model.UserModel) (*model.UserModel, error)
+	// SetTenant назначает пользователю организацию; пустая строка убирает ее. Если version не 0
+	// и не совпадает с версией пользователя, возвращается ErrVersionMismatch.
+	// Если пользователь не найден, возвращается ErrUserNotFound.
+	SetTenant(ctx context.Context, id int, tenant string, version int) (*model.UserModel, error)
+	// Delete удаляет пользователя или возвращает ErrUserNotFound. Если version не 0
+	// и не совпадает с версией пользователя, возвращается ErrVersionMismatch.
+	Delete(ctx context.Context, id int, version int) error
 }
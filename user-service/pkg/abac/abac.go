@@ -0,0 +1,160 @@
+package abac
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Effect — результат правила: разрешить или запретить действие
+type Effect string
+
+const (
+	Allow Effect = "allow"
+	Deny  Effect = "deny"
+)
+
+// anyAction в списке действий правила означает любое действие
+const anyAction string = "*"
+
+// Attributes содержит атрибуты субъекта или ресурса.
+// Значения — строки или списки строк.
+type Attributes map[string]any
+
+// Request описывает проверяемое обращение: кто (Subject), что делает (Action) и с чем (Resource)
+type Request struct {
+	Subject  Attributes
+	Action   string
+	Resource Attributes
+}
+
+// Rule — правило политики. Правило применяется, если действие входит в Actions
+// и выполняются все условия Conditions.
+type Rule struct {
+	Name        string      `yaml:"name"`
+	Description string      `yaml:"description"`
+	Effect      Effect      `yaml:"effect"`
+	Actions     []string    `yaml:"actions"`
+	Conditions  []Condition `yaml:"conditions"`
+}
+
+// RuleTrace объясняет, почему правило применилось или нет
+type RuleTrace struct {
+	Rule    string
+	Effect  Effect
+	Matched bool
+	Reasons []string
+}
+
+// Decision — решение по обращению с объяснением по каждому правилу
+type Decision struct {
+	Allowed bool
+	Rule    string // Правило, определившее решение; пусто, если не применилось ни одно
+	Reason  string
+	Trace   []RuleTrace
+}
+
+// Engine вычисляет решения по набору правил.
+// Запрещающие правила имеют приоритет над разрешающими,
+// если не применилось ни одно правило, действие запрещено.
+type Engine struct {
+	rules []Rule
+}
+
+// Evaluate вычисляет решение по обращению request
+func (engine *Engine) Evaluate(request Request) Decision {
+	decision := Decision{
+		Trace: make([]RuleTrace, 0, len(engine.rules)),
+	}
+
+	var allowRule string
+	var denyRule string
+	for _, rule := range engine.rules {
+		trace := rule.evaluate(request)
+		decision.Trace = append(decision.Trace, trace)
+		if !trace.Matched {
+			continue
+		}
+		switch {
+		case rule.Effect == Deny && denyRule == "":
+			denyRule = rule.Name
+		case rule.Effect == Allow && allowRule == "":
+			allowRule = rule.Name
+		}
+	}
+
+	switch {
+	case denyRule != "":
+		decision.Rule = denyRule
+		decision.Reason = fmt.Sprintf("действие '%s' запрещено правилом '%s'", request.Action, denyRule)
+	case allowRule != "":
+		decision.Allowed = true
+		decision.Rule = allowRule
+		decision.Reason = fmt.Sprintf("действие '%s' разрешено правилом '%s'", request.Action, allowRule)
+	default:
+		decision.Reason = fmt.Sprintf("ни одно правило не разрешает действие '%s'", request.Action)
+	}
+	return decision
+}
+
+// Rules возвращает правила движка
+func (engine *Engine) Rules() []Rule {
+	return slices.Clone(engine.rules)
+}
+
+// evaluate проверяет применимость правила и собирает объяснение
+func (rule *Rule) evaluate(request Request) RuleTrace {
+	trace := RuleTrace{
+		Rule:   rule.Name,
+		Effect: rule.Effect,
+	}
+
+	if !slices.Contains(rule.Actions, anyAction) && !slices.Contains(rule.Actions, request.Action) {
+		trace.Reasons = append(trace.Reasons, fmt.Sprintf("действие '%s' не входит в %v", request.Action, rule.Actions))
+		return trace
+	}
+
+	trace.Matched = true
+	for _, condition := range rule.Conditions {
+		isMet, reason := condition.evaluate(request)
+		trace.Reasons = append(trace.Reasons, reason)
+		if !isMet {
+			trace.Matched = false
+		}
+	}
+	return trace
+}
+
+// New создает движок с правилами rules после проверки их корректности
+func New(rules []Rule) (*Engine, error) {
+	names := make(map[string]bool, len(rules))
+	for index, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("правило #%d: %w", index+1, ErrMissingRuleName)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("правило '%s': %w", rule.Name, ErrDuplicateRuleName)
+		}
+		names[rule.Name] = true
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("правило '%s': %w", rule.Name, err)
+		}
+	}
+	return &Engine{
+		rules: slices.Clone(rules),
+	}, nil
+}
+
+func (rule *Rule) validate() error {
+	if rule.Effect != Allow && rule.Effect != Deny {
+		return fmt.Errorf("%w: '%s'", ErrInvalidEffect, rule.Effect)
+	}
+	if len(rule.Actions) == 0 {
+		return ErrMissingActions
+	}
+	for _, condition := range rule.Conditions {
+		if err := condition.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
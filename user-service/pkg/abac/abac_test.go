@@ -0,0 +1,154 @@
+package abac
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+const testPolicies string = `
+rules:
+  - name: admin-all
+    effect: allow
+    actions: ["*"]
+    conditions:
+      - attribute: subject.roles
+        operator: contains
+        value: admin
+  - name: owner
+    effect: allow
+    actions: [users:read, users:update, users:update_password]
+    conditions:
+      - attribute: subject.id
+        operator: eq
+        value_from: resource.id
+  - name: support-read-own-tenant
+    effect: allow
+    actions: [users:read, users:update_password]
+    conditions:
+      - attribute: subject.roles
+        operator: contains
+        value: support
+      - attribute: subject.tenant
+        operator: ne
+        value: ""
+      - attribute: subject.tenant
+        operator: eq
+        value_from: resource.tenant
+  - name: support-no-password-change
+    effect: deny
+    actions: [users:update_password]
+    conditions:
+      - attribute: subject.roles
+        operator: contains
+        value: support
+      - attribute: subject.id
+        operator: ne
+        value_from: resource.id
+`
+
+func TestEvaluate(t *testing.T) {
+	engine, err := Parse([]byte(testPolicies))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	support := Attributes{"id": "1", "roles": []string{"support"}, "tenant": "acme"}
+	sameTenant := Attributes{"id": 2, "tenant": "acme"}
+	otherTenant := Attributes{"id": 3, "tenant": "globex"}
+
+	tests := []struct {
+		name    string
+		request Request
+		allowed bool
+		rule    string
+	}{
+		{
+			name:    "Support reads user in own tenant",
+			request: Request{Subject: support, Action: "users:read", Resource: sameTenant},
+			allowed: true,
+			rule:    "support-read-own-tenant",
+		},
+		{
+			name:    "Support reads user in other tenant",
+			request: Request{Subject: support, Action: "users:read", Resource: otherTenant},
+			allowed: false,
+		},
+		{
+			name:    "Deny overrides allow",
+			request: Request{Subject: support, Action: "users:update_password", Resource: sameTenant},
+			allowed: false,
+			rule:    "support-no-password-change",
+		},
+		{
+			name:    "Owner changes own password",
+			request: Request{Subject: support, Action: "users:update_password", Resource: Attributes{"id": 1, "tenant": "acme"}},
+			allowed: true,
+			rule:    "owner",
+		},
+		{
+			name:    "Admin",
+			request: Request{Subject: Attributes{"id": "9", "roles": []any{"admin"}}, Action: "users:delete", Resource: otherTenant},
+			allowed: true,
+			rule:    "admin-all",
+		},
+		{
+			name:    "No tenant",
+			request: Request{Subject: Attributes{"id": "5", "roles": []string{"support"}, "tenant": ""}, Action: "users:read", Resource: Attributes{"id": 6, "tenant": ""}},
+			allowed: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decision := engine.Evaluate(test.request)
+			assert.Equal(t, decision.Allowed, test.allowed)
+			assert.Equal(t, decision.Rule, test.rule)
+			assert.Equal(t, len(decision.Trace), 4)
+			assert.NotEqual(t, decision.Reason, "")
+		})
+	}
+}
+
+func TestTrace(t *testing.T) {
+	engine, err := Parse([]byte(testPolicies))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decision := engine.Evaluate(Request{
+		Subject:  Attributes{"id": "1", "roles": []string{"support"}, "tenant": "acme"},
+		Action:   "users:read",
+		Resource: Attributes{"id": 3, "tenant": "globex"},
+	})
+
+	trace := decision.Trace[2]
+	assert.Equal(t, trace.Rule, "support-read-own-tenant")
+	assert.Equal(t, trace.Matched, false)
+	assert.Equal(t, trace.Reasons[2], "subject.tenant (acme) eq resource.tenant (globex): не выполнено")
+
+	assert.Equal(t, decision.Trace[0].Reasons[0], "subject.roles ([support]) contains admin: не выполнено")
+	assert.Equal(t, decision.Trace[3].Reasons[0], "действие 'users:read' не входит в [users:update_password]")
+}
+
+func TestInvalidPolicies(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		err    error
+	}{
+		{name: "Missing name", policy: "rules: [{effect: allow, actions: [a]}]", err: ErrMissingRuleName},
+		{name: "Duplicate name", policy: "rules: [{name: a, effect: allow, actions: [a]}, {name: a, effect: deny, actions: [a]}]", err: ErrDuplicateRuleName},
+		{name: "Effect", policy: "rules: [{name: a, effect: maybe, actions: [a]}]", err: ErrInvalidEffect},
+		{name: "Actions", policy: "rules: [{name: a, effect: allow}]", err: ErrMissingActions},
+		{name: "Attribute", policy: "rules: [{name: a, effect: allow, actions: [a], conditions: [{attribute: roles, operator: eq, value: x}]}]", err: ErrInvalidAttribute},
+		{name: "Operator", policy: "rules: [{name: a, effect: allow, actions: [a], conditions: [{attribute: subject.roles, operator: like, value: x}]}]", err: ErrUnsupportedOperator},
+		{name: "Value", policy: "rules: [{name: a, effect: allow, actions: [a], conditions: [{attribute: subject.id, operator: eq}]}]", err: ErrInvalidValue},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse([]byte(test.policy))
+			assert.Equal(t, errors.Is(err, test.err), true)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package abac
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Operator — оператор сравнения в условии правила
+type Operator string
+
+const (
+	Equal       Operator = "eq"       // значения равны
+	NotEqual    Operator = "ne"       // значения не равны
+	In          Operator = "in"       // значение атрибута входит в список
+	NotIn       Operator = "not_in"   // значение атрибута не входит в список
+	Contains    Operator = "contains" // список в атрибуте содержит значение
+	NotContains Operator = "not_contains"
+)
+
+const (
+	subjectPrefix  string = "subject."
+	resourcePrefix string = "resource."
+)
+
+// Condition сравнивает атрибут с константой Value или с другим атрибутом ValueFrom.
+// Атрибуты задаются как subject.<имя> или resource.<имя>.
+type Condition struct {
+	Attribute string   `yaml:"attribute"`
+	Operator  Operator `yaml:"operator"`
+	Value     any      `yaml:"value"`
+	ValueFrom string   `yaml:"value_from"`
+}
+
+// evaluate проверяет условие и возвращает пояснение
+func (condition *Condition) evaluate(request Request) (bool, string) {
+	actual, isFound := request.lookup(condition.Attribute)
+	if !isFound {
+		return false, fmt.Sprintf("атрибут %s не задан", condition.Attribute)
+	}
+
+	expected := condition.Value
+	expectedName := fmt.Sprintf("%v", condition.Value)
+	if condition.ValueFrom != "" {
+		expected, isFound = request.lookup(condition.ValueFrom)
+		if !isFound {
+			return false, fmt.Sprintf("атрибут %s не задан", condition.ValueFrom)
+		}
+		expectedName = fmt.Sprintf("%s (%v)", condition.ValueFrom, expected)
+	}
+
+	isMet := compare(condition.Operator, normalize(actual), normalize(expected))
+	result := "выполнено"
+	if !isMet {
+		result = "не выполнено"
+	}
+	return isMet, fmt.Sprintf("%s (%v) %s %s: %s", condition.Attribute, actual, condition.Operator, expectedName, result)
+}
+
+func (condition *Condition) validate() error {
+	if !isAttributeName(condition.Attribute) {
+		return fmt.Errorf("%w: '%s'", ErrInvalidAttribute, condition.Attribute)
+	}
+	if condition.ValueFrom != "" && !isAttributeName(condition.ValueFrom) {
+		return fmt.Errorf("%w: '%s'", ErrInvalidAttribute, condition.ValueFrom)
+	}
+	if (condition.Value == nil) == (condition.ValueFrom == "") {
+		return fmt.Errorf("%w: %s", ErrInvalidValue, condition.Attribute)
+	}
+	switch condition.Operator {
+	case Equal, NotEqual, In, NotIn, Contains, NotContains:
+		return nil
+	default:
+		return fmt.Errorf("%w: '%s'", ErrUnsupportedOperator, condition.Operator)
+	}
+}
+
+func isAttributeName(attribute string) bool {
+	return strings.HasPrefix(attribute, subjectPrefix) || strings.HasPrefix(attribute, resourcePrefix)
+}
+
+// lookup возвращает атрибут субъекта или ресурса по полному имени
+func (request *Request) lookup(attribute string) (any, bool) {
+	var attributes Attributes
+	var name string
+	switch {
+	case strings.HasPrefix(attribute, subjectPrefix):
+		attributes, name = request.Subject, strings.TrimPrefix(attribute, subjectPrefix)
+	case strings.HasPrefix(attribute, resourcePrefix):
+		attributes, name = request.Resource, strings.TrimPrefix(attribute, resourcePrefix)
+	default:
+		return nil, false
+	}
+	value, isFound := attributes[name]
+	return value, isFound
+}
+
+// normalize приводит значение к строке или списку строк,
+// чтобы "7" из токена и 7 из базы данных считались равными
+func normalize(value any) []string {
+	switch typedValue := value.(type) {
+	case []string:
+		return typedValue
+	case []any:
+		values := make([]string, 0, len(typedValue))
+		for _, item := range typedValue {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values
+	default:
+		return []string{fmt.Sprint(typedValue)}
+	}
+}
+
+// compare применяет оператор. Для eq и ne значения сравниваются целиком,
+// для in и contains проверяется вхождение каждого элемента одной стороны в другую.
+func compare(operator Operator, actual, expected []string) bool {
+	switch operator {
+	case Equal:
+		return slices.Equal(actual, expected)
+	case NotEqual:
+		return !slices.Equal(actual, expected)
+	case In:
+		return isSubset(actual, expected)
+	case NotIn:
+		return !containsAny(expected, actual)
+	case Contains:
+		return isSubset(expected, actual)
+	case NotContains:
+		return !containsAny(actual, expected)
+	default:
+		return false
+	}
+}
+
+// isSubset сообщает, что все элементы values входят в set
+func isSubset(values, set []string) bool {
+	for _, value := range values {
+		if !slices.Contains(set, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsAny сообщает, что хотя бы один элемент values входит в set
+func containsAny(set, values []string) bool {
+	for _, value := range values {
+		if slices.Contains(set, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package abac
+
+import "errors"
+
+var (
+	ErrMissingRuleName     = errors.New("не задано имя правила")
+	ErrDuplicateRuleName   = errors.New("имя правила повторяется")
+	ErrInvalidEffect       = errors.New("эффект правила должен быть allow или deny")
+	ErrMissingActions      = errors.New("не заданы действия правила")
+	ErrInvalidAttribute    = errors.New("атрибут должен начинаться с subject. или resource.")
+	ErrUnsupportedOperator = errors.New("неподдерживаемый оператор условия")
+	ErrInvalidValue        = errors.New("в условии должно быть задано ровно одно из value и value_from")
+)
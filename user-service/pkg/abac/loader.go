@@ -0,0 +1,30 @@
+package abac
+
+import (
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// policyFile — структура YAML-файла политик
+type policyFile struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Load читает правила из YAML-файла path и создает движок
+func Load(path string) (*Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse разбирает правила в формате YAML и создает движок
+func Parse(data []byte) (*Engine, error) {
+	var file policyFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	return New(file.Rules)
+}
@@ -0,0 +1,35 @@
+package cursor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidCursor error = errors.New("некорректный курсор")
+
+// Encode кодирует положение в списке value в непрозрачную строку курсора:
+// JSON в base64url без выравнивания, пригодный для строки запроса без экранирования
+func Encode(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Decode читает курсор, полученный от Encode, в value. Курсор приходит от клиента,
+// поэтому любая ошибка разбора, в том числе неизвестные поля, возвращается как ErrInvalidCursor.
+func Decode(cursor string, value any) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil || decoder.More() {
+		return ErrInvalidCursor
+	}
+	return nil
+}
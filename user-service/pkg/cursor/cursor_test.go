@@ -0,0 +1,39 @@
+package cursor
+
+import (
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+type position struct {
+	After  int `json:"a,omitempty"`
+	Before int `json:"b,omitempty"`
+}
+
+func TestCursor(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		encoded, err := Encode(position{After: 42})
+		assert.Equal(t, err, nil)
+
+		var decoded position
+		assert.Equal(t, Decode(encoded, &decoded), nil)
+		assert.Equal(t, decoded, position{After: 42})
+	})
+
+	t.Run("URL safe", func(t *testing.T) {
+		encoded, err := Encode(map[string]string{"k": "???"})
+		assert.Equal(t, err, nil)
+		assert.Equal(t, encoded, "eyJrIjoiPz8_In0")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		var decoded position
+		assert.Equal(t, Decode("not base64!", &decoded), ErrInvalidCursor)
+		assert.Equal(t, Decode("eyJhIjo", &decoded), ErrInvalidCursor)
+		// {"x":1}
+		assert.Equal(t, Decode("eyJ4IjoxfQ", &decoded), ErrInvalidCursor)
+		// {"a":1}{}
+		assert.Equal(t, Decode("eyJhIjoxfXt9", &decoded), ErrInvalidCursor)
+	})
+}
@@ -1,7 +1,22 @@
 package db
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+)
 
 type DB interface {
-	OpenConnect() (*sql.DB, error)
+	UnitOfWork
+	// Pool возвращает общий пул соединений, созданный при запуске.
+	// Соединения возвращаются в пул автоматически, закрывать пул после запроса не нужно.
+	Pool() *sql.DB
+	// Querier возвращает транзакцию, начатую UnitOfWork.Do для ctx, а если ее нет — пул соединений.
+	// Репозитории выполняют запросы через Querier, чтобы участвовать в общей транзакции.
+	Querier(ctx context.Context) Querier
+	// WithQueryTimeout ограничивает ctx временем выполнения запроса из настроек.
+	// Возвращаемую функцию отмены нужно вызвать после завершения запроса.
+	WithQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc)
+	Ping(ctx context.Context) error
+	Stats() sql.DBStats
+	Close() error
 }
@@ -1,47 +1,77 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"time"
 	"user-service/internal/config"
 
 	_ "github.com/lib/pq"
 )
 
 type dbImpl struct {
-	host     string
-	port     int
-	user     string
-	password string
-	dbname   string
-	sslmode  string
+	pool         *sql.DB
+	queryTimeout time.Duration
 }
 
-func (db dbImpl) OpenConnect() (*sql.DB, error) {
+func (db *dbImpl) Pool() *sql.DB {
+	return db.pool
+}
+
+func (db *dbImpl) WithQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if db.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, db.queryTimeout)
+}
+
+func (db *dbImpl) Ping(ctx context.Context) error {
+	return db.pool.PingContext(ctx)
+}
+
+func (db *dbImpl) Stats() sql.DBStats {
+	return db.pool.Stats()
+}
+
+func (db *dbImpl) Close() error {
+	return db.pool.Close()
+}
+
+// New создает пул соединений с параметрами из dbConfig.
+// Если включена проверка при запуске, пул пингуется, чтобы ошибки
+// подключения обнаруживались сразу, а не на первом запросе.
+func New(dbConfig config.DatabaseConfig) (DB, error) {
 	dataSourceName := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		db.host,
-		db.port,
-		db.user,
-		db.password,
-		db.dbname,
-		db.sslmode,
+		dbConfig.GetHost(),
+		dbConfig.GetPort(),
+		dbConfig.GetUser(),
+		dbConfig.GetPassword(),
+		dbConfig.GetDBName(),
+		dbConfig.GetSSLMode(),
 	)
-	conn, err := sql.Open("postgres", dataSourceName)
+	pool, err := sql.Open("postgres", dataSourceName)
 	if err != nil {
 		return nil, err
 	}
-	return conn, nil
-}
 
-func New(dbConfig config.DatabaseConfig) (DB, error) {
+	pool.SetMaxOpenConns(dbConfig.GetMaxOpenConns())
+	pool.SetMaxIdleConns(dbConfig.GetMaxIdleConns())
+	pool.SetConnMaxLifetime(dbConfig.GetConnMaxLifetime())
+	pool.SetConnMaxIdleTime(dbConfig.GetConnMaxIdleTime())
+
+	if dbConfig.GetPingOnStart() {
+		ctx, cancel := context.WithTimeout(context.Background(), dbConfig.GetPingTimeout())
+		defer cancel()
+		if err := pool.PingContext(ctx); err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("база данных недоступна: %w", err)
+		}
+	}
+
 	return &dbImpl{
-			host:     dbConfig.GetHost(),
-			port:     dbConfig.GetPort(),
-			user:     dbConfig.GetUser(),
-			password: dbConfig.GetPassword(),
-			dbname:   dbConfig.GetDBName(),
-			sslmode:  dbConfig.GetSSLMode(),
-		},
-		nil
+		pool:         pool,
+		queryTimeout: dbConfig.GetQueryTimeout(),
+	}, nil
 }
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// Коды ошибок PostgreSQL (SQLSTATE), которые репозитории преобразуют в ошибки предметной области
+const (
+	uniqueViolation     pq.ErrorCode = "23505"
+	foreignKeyViolation pq.ErrorCode = "23503"
+)
+
+// IsUniqueViolation сообщает, что запрос нарушил ограничение уникальности,
+// и возвращает имя нарушенного ограничения
+func IsUniqueViolation(err error) (string, bool) {
+	return constraintViolation(err, uniqueViolation)
+}
+
+// IsForeignKeyViolation сообщает, что запрос нарушил ограничение внешнего ключа,
+// и возвращает имя нарушенного ограничения
+func IsForeignKeyViolation(err error) (string, bool) {
+	return constraintViolation(err, foreignKeyViolation)
+}
+
+func constraintViolation(err error, code pq.ErrorCode) (string, bool) {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == code {
+		return pqErr.Constraint, true
+	}
+	return "", false
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Querier — запросы, общие для пула соединений и транзакции
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// UnitOfWork выполняет несколько операций репозиториев в одной транзакции
+type UnitOfWork interface {
+	// Do выполняет action в транзакции, доступной репозиториям через переданный в action контекст.
+	// Транзакция фиксируется, если action вернул nil, и откатывается в остальных случаях.
+	// Вложенный вызов Do выполняется в уже начатой транзакции.
+	Do(ctx context.Context, action func(ctx context.Context) error) error
+}
+
+type txContextKey struct{}
+
+func (db *dbImpl) Do(ctx context.Context, action func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return action(ctx)
+	}
+
+	tx, err := db.pool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := action(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (db *dbImpl) Querier(ctx context.Context) Querier {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db.pool
+}
@@ -0,0 +1,37 @@
+package http_helper
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// NotModified проверяет условия If-None-Match и If-Modified-Since запроса GET или HEAD
+// (RFC 9110, раздел 13.2.2) для текущих etag и lastModified. Возвращает true, если у клиента
+// актуальная копия и достаточно ответа 304 Not Modified. If-None-Match сравнивает теги
+// нестрого, а If-Modified-Since учитывается, только если If-None-Match не передан.
+// Нулевой lastModified означает, что время изменения неизвестно.
+func NotModified(request *http.Request, etag string, lastModified time.Time) bool {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		return false
+	}
+
+	if ifNoneMatch := request.Header.Values("If-None-Match"); len(ifNoneMatch) > 0 {
+		for _, tag := range parseETags(ifNoneMatch) {
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+	ifModifiedSince, err := http.ParseTime(request.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	// В HTTP-датах нет долей секунды
+	return !lastModified.Truncate(time.Second).After(ifModifiedSince)
+}
@@ -0,0 +1,59 @@
+package http_helper
+
+import "strings"
+
+// ETag возвращает сильный тег сущности для значения value
+func ETag(value string) string {
+	return `"` + value + `"`
+}
+
+// IfMatch проверяет условие заголовка If-Match (RFC 9110, раздел 13.1.1) для текущего тега etag.
+// Условие выполняется, если заголовок пуст, равен "*" или содержит etag. Теги сравниваются
+// строго: слабые теги (W/) не совпадают ни с одним тегом.
+func IfMatch(header []string, etag string) bool {
+	tags := parseETags(header)
+	if len(tags) == 0 {
+		return true
+	}
+	for _, tag := range tags {
+		if tag == "*" || (!isWeak(tag) && !isWeak(etag) && tag == etag) {
+			return true
+		}
+	}
+	return false
+}
+
+func isWeak(tag string) bool {
+	return strings.HasPrefix(tag, "W/")
+}
+
+// parseETags разбирает значения заголовка со списком тегов сущностей.
+// Запятые внутри кавычек не разделяют теги.
+func parseETags(header []string) []string {
+	var tags []string
+	for _, value := range header {
+		var tag strings.Builder
+		quoted := false
+		for _, char := range value {
+			switch {
+			case char == '"':
+				quoted = !quoted
+				tag.WriteRune(char)
+			case char == ',' && !quoted:
+				tags = appendTag(tags, tag.String())
+				tag.Reset()
+			default:
+				tag.WriteRune(char)
+			}
+		}
+		tags = appendTag(tags, tag.String())
+	}
+	return tags
+}
+
+func appendTag(tags []string, tag string) []string {
+	if tag = strings.TrimSpace(tag); tag != "" {
+		tags = append(tags, tag)
+	}
+	return tags
+}
@@ -0,0 +1,84 @@
+package http_helper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestIfMatch(t *testing.T) {
+	etag := ETag("3")
+
+	t.Run("No header", func(t *testing.T) {
+		assert.Equal(t, IfMatch(nil, etag), true)
+	})
+
+	t.Run("Any", func(t *testing.T) {
+		assert.Equal(t, IfMatch([]string{"*"}, etag), true)
+	})
+
+	t.Run("Match", func(t *testing.T) {
+		assert.Equal(t, IfMatch([]string{`"3"`}, etag), true)
+		assert.Equal(t, IfMatch([]string{`"1", "3"`}, etag), true)
+		assert.Equal(t, IfMatch([]string{`"1"`, `"3"`}, etag), true)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		assert.Equal(t, IfMatch([]string{`"2"`}, etag), false)
+		assert.Equal(t, IfMatch([]string{`"3,4"`}, etag), false)
+	})
+
+	t.Run("Weak tag", func(t *testing.T) {
+		assert.Equal(t, IfMatch([]string{`W/"3"`}, etag), false)
+	})
+}
+
+func TestNotModified(t *testing.T) {
+	etag := ETag("3")
+	lastModified := time.Date(2024, time.March, 1, 12, 0, 0, 500, time.UTC)
+
+	newRequest := func(method string, header map[string]string) *http.Request {
+		req := httptest.NewRequest(method, "/users/1", nil)
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+		return req
+	}
+
+	t.Run("No conditions", func(t *testing.T) {
+		assert.Equal(t, NotModified(newRequest(http.MethodGet, nil), etag, lastModified), false)
+	})
+
+	t.Run("If-None-Match", func(t *testing.T) {
+		assert.Equal(t, NotModified(newRequest(http.MethodGet, map[string]string{"If-None-Match": `"1", "3"`}), etag, lastModified), true)
+		assert.Equal(t, NotModified(newRequest(http.MethodGet, map[string]string{"If-None-Match": `W/"3"`}), etag, lastModified), true)
+		assert.Equal(t, NotModified(newRequest(http.MethodGet, map[string]string{"If-None-Match": "*"}), etag, lastModified), true)
+		assert.Equal(t, NotModified(newRequest(http.MethodGet, map[string]string{"If-None-Match": `"2"`}), etag, lastModified), false)
+	})
+
+	t.Run("If-Modified-Since", func(t *testing.T) {
+		assert.Equal(t, NotModified(newRequest(http.MethodGet, map[string]string{
+			"If-Modified-Since": lastModified.Format(http.TimeFormat),
+		}), etag, lastModified), true)
+		assert.Equal(t, NotModified(newRequest(http.MethodGet, map[string]string{
+			"If-Modified-Since": lastModified.Add(-time.Second).Format(http.TimeFormat),
+		}), etag, lastModified), false)
+		assert.Equal(t, NotModified(newRequest(http.MethodGet, map[string]string{
+			"If-Modified-Since": lastModified.Format(http.TimeFormat),
+		}), etag, time.Time{}), false)
+	})
+
+	t.Run("If-None-Match takes precedence", func(t *testing.T) {
+		assert.Equal(t, NotModified(newRequest(http.MethodGet, map[string]string{
+			"If-None-Match":     `"2"`,
+			"If-Modified-Since": lastModified.Format(http.TimeFormat),
+		}), etag, lastModified), false)
+	})
+
+	t.Run("Unsafe method", func(t *testing.T) {
+		assert.Equal(t, NotModified(newRequest(http.MethodPut, map[string]string{"If-None-Match": `"3"`}), etag, lastModified), false)
+	})
+}
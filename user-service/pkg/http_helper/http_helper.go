@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 var ErrParamIsEmpty error = errors.New("query param is empty")
@@ -34,6 +35,10 @@ func convertParam[T any](param string) (T, error) {
 		*value, err = strconv.Atoi(param)
 	case *string:
 		*value = param
+	case *bool:
+		*value, err = strconv.ParseBool(param)
+	case *time.Time:
+		*value, err = time.Parse(time.RFC3339, param)
 	default:
 		err = ErrUnsupportedType
 	}
@@ -0,0 +1,7 @@
+package http_helper
+
+// Link возвращает значение заголовка Link (RFC 8288) со ссылкой target и отношением rel.
+// target должен быть URI-ссылкой, то есть уже закодирован.
+func Link(target string, rel string) string {
+	return "<" + target + `>; rel="` + rel + `"`
+}
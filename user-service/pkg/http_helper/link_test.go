@@ -0,0 +1,11 @@
+package http_helper
+
+import (
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestLink(t *testing.T) {
+	assert.Equal(t, Link("/users/all?cursor=eyJhIjoyfQ&limit=1", "next"), `</users/all?cursor=eyJhIjoyfQ&limit=1>; rel="next"`)
+}
@@ -0,0 +1,110 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"golang.org/x/text/language"
+)
+
+var ErrUnsupportedLanguage = errors.New("язык отсутствует в каталоге сообщений")
+
+type contextKey struct{}
+
+// Catalog хранит переводы сообщений по стабильным ключам и выбирает язык ответа
+// по заголовку Accept-Language. Если перевод на выбранный язык отсутствует,
+// используется язык по умолчанию, а затем сам ключ.
+type Catalog struct {
+	defaultLanguage string
+	languages       []string // Язык по умолчанию всегда первый
+	matcher         language.Matcher
+	messages        map[string]map[string]string
+}
+
+// DefaultLanguage возвращает язык по умолчанию
+func (catalog *Catalog) DefaultLanguage() string {
+	return catalog.defaultLanguage
+}
+
+// Negotiate выбирает язык ответа по значению заголовка Accept-Language с учетом весов q.
+// Если заголовок пуст, некорректен или не содержит поддерживаемых языков, возвращается язык по умолчанию.
+func (catalog *Catalog) Negotiate(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return catalog.defaultLanguage
+	}
+	_, index, confidence := catalog.matcher.Match(tags...)
+	if confidence == language.No {
+		return catalog.defaultLanguage
+	}
+	return catalog.languages[index]
+}
+
+// Lookup возвращает сообщение key на языке lang, подставляя args по правилам fmt.Sprintf
+func (catalog *Catalog) Lookup(lang string, key string, args ...any) (string, bool) {
+	message, ok := catalog.messages[lang][key]
+	if !ok {
+		message, ok = catalog.messages[catalog.defaultLanguage][key]
+	}
+	if !ok {
+		return "", false
+	}
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	return message, true
+}
+
+// Translate возвращает сообщение key на языке lang или сам ключ, если сообщение не найдено
+func (catalog *Catalog) Translate(lang string, key string, args ...any) string {
+	if message, ok := catalog.Lookup(lang, key, args...); ok {
+		return message
+	}
+	return key
+}
+
+// WithLanguage сохраняет язык ответа в контексте запроса
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, contextKey{}, lang)
+}
+
+// LanguageFromContext возвращает язык, сохраненный WithLanguage, или пустую строку
+func LanguageFromContext(ctx context.Context) string {
+	lang, _ := ctx.Value(contextKey{}).(string)
+	return lang
+}
+
+// New создает каталог сообщений. Ключи messages — коды языков по BCP 47 (ru, en),
+// значения — сообщения по ключам. Язык defaultLanguage должен присутствовать в messages.
+func New(defaultLanguage string, messages map[string]map[string]string) (*Catalog, error) {
+	if _, ok := messages[defaultLanguage]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLanguage, defaultLanguage)
+	}
+
+	languages := make([]string, 0, len(messages))
+	for lang := range messages {
+		if lang != defaultLanguage {
+			languages = append(languages, lang)
+		}
+	}
+	sort.Strings(languages)
+	languages = append([]string{defaultLanguage}, languages...)
+
+	tags := make([]language.Tag, 0, len(languages))
+	for _, lang := range languages {
+		tag, err := language.Parse(lang)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedLanguage, lang)
+		}
+		tags = append(tags, tag)
+	}
+
+	return &Catalog{
+		defaultLanguage: defaultLanguage,
+		languages:       languages,
+		matcher:         language.NewMatcher(tags),
+		messages:        messages,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+var testMessages = map[string]map[string]string{
+	"ru": {
+		"greeting": "Привет, %s!",
+		"farewell": "Пока",
+	},
+	"en": {
+		"greeting": "Hello, %s!",
+	},
+}
+
+func TestNegotiate(t *testing.T) {
+	catalog, err := New("ru", testMessages)
+	assert.Equal(t, err, nil)
+
+	t.Run("Empty header", func(t *testing.T) {
+		assert.Equal(t, catalog.Negotiate(""), "ru")
+	})
+
+	t.Run("Exact match", func(t *testing.T) {
+		assert.Equal(t, catalog.Negotiate("en"), "en")
+	})
+
+	t.Run("Regional variant", func(t *testing.T) {
+		assert.Equal(t, catalog.Negotiate("en-GB,en;q=0.8"), "en")
+	})
+
+	t.Run("Quality weights", func(t *testing.T) {
+		assert.Equal(t, catalog.Negotiate("ru;q=0.5, en;q=0.9"), "en")
+		assert.Equal(t, catalog.Negotiate("ru, en;q=0.9"), "ru")
+	})
+
+	t.Run("Unsupported language", func(t *testing.T) {
+		assert.Equal(t, catalog.Negotiate("de-DE"), "ru")
+	})
+
+	t.Run("Invalid header", func(t *testing.T) {
+		assert.Equal(t, catalog.Negotiate(";;;q=abc"), "ru")
+	})
+}
+
+func TestTranslate(t *testing.T) {
+	catalog, err := New("ru", testMessages)
+	assert.Equal(t, err, nil)
+
+	t.Run("Arguments", func(t *testing.T) {
+		assert.Equal(t, catalog.Translate("en", "greeting", "Bob"), "Hello, Bob!")
+		assert.Equal(t, catalog.Translate("ru", "greeting", "Боб"), "Привет, Боб!")
+	})
+
+	t.Run("Fallback to default language", func(t *testing.T) {
+		assert.Equal(t, catalog.Translate("en", "farewell"), "Пока")
+		assert.Equal(t, catalog.Translate("de", "farewell"), "Пока")
+	})
+
+	t.Run("Unknown key", func(t *testing.T) {
+		assert.Equal(t, catalog.Translate("en", "unknown"), "unknown")
+		_, ok := catalog.Lookup("en", "unknown")
+		assert.Equal(t, ok, false)
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		assert.Equal(t, LanguageFromContext(context.Background()), "")
+		ctx := WithLanguage(context.Background(), "en")
+		assert.Equal(t, LanguageFromContext(ctx), "en")
+	})
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("de", testMessages)
+	assert.Equal(t, errors.Is(err, ErrUnsupportedLanguage), true)
+}
@@ -2,13 +2,19 @@ package jwt
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/hmac"
-	"crypto/sha256"
-	"crypto/sha512"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
-	"hash"
+	"math/big"
 	"user-service/pkg/jwt/jwt_errors"
 	"user-service/pkg/jwt/jwt_metadata"
 )
@@ -22,30 +28,115 @@ func serializeToBase64[T jwt_metadata.SerializebleBase64](data *T) (*string, err
 	return &encodeData, nil
 }
 
-func generateSignature(encodedHeader, encodedPayload, algorithm, secret *string) []byte {
-	var hash hash.Hash
-	bytesSecret := []byte(*secret)
+// algorithmHash возвращает хеш-функцию, используемую алгоритмом подписи
+func algorithmHash(algorithm string) (crypto.Hash, error) {
+	switch algorithm {
+	case "HS256", "RS256", "PS256", "ES256":
+		return crypto.SHA256, nil
+	case "HS384", "RS384", "PS384", "ES384":
+		return crypto.SHA384, nil
+	case "HS512", "RS512", "PS512":
+		return crypto.SHA512, nil
+	case "EdDSA":
+		return 0, nil
+	default:
+		return 0, jwt_errors.ErrUnsupportedAlgorithm
+	}
+}
 
-	switch *algorithm {
-	case "HS256":
-		hash = hmac.New(sha256.New, bytesSecret)
-	case "HS384":
-		hash = hmac.New(sha512.New384, bytesSecret)
-	case "HS512":
-		hash = hmac.New(sha512.New, bytesSecret)
+// algorithmCurve возвращает кривую, которую требует ECDSA-алгоритм подписи
+func algorithmCurve(algorithm string) elliptic.Curve {
+	switch algorithm {
+	case "ES256":
+		return elliptic.P256()
+	case "ES384":
+		return elliptic.P384()
 	default:
-		panic(jwt_errors.ErrUnsupportedAlgorithm)
+		return nil
 	}
+}
 
+func signingInput(encodedHeader, encodedPayload *string) []byte {
 	var buffer bytes.Buffer
 	buffer.WriteString(*encodedHeader)
 	buffer.WriteRune('.')
 	buffer.WriteString(*encodedPayload)
-	buffer.WriteTo(hash)
+	return buffer.Bytes()
+}
 
+func digest(hashType crypto.Hash, data []byte) []byte {
+	hash := hashType.New()
+	hash.Write(data)
 	return hash.Sum(nil)
 }
 
+func generateSignature(encodedHeader, encodedPayload, algorithm *string, key *Key) ([]byte, error) {
+	hashType, err := algorithmHash(*algorithm)
+	if err != nil {
+		return nil, err
+	}
+	data := signingInput(encodedHeader, encodedPayload)
+
+	switch (*algorithm)[:2] {
+	case "HS":
+		if len(key.secret) == 0 {
+			return nil, jwt_errors.ErrMissingSigningKey
+		}
+		hash := hmac.New(hashType.New, key.secret)
+		hash.Write(data)
+		return hash.Sum(nil), nil
+	case "RS":
+		privateKey, ok := key.privateKey.(*rsa.PrivateKey)
+		if !ok {
+			return nil, signingKeyError(key)
+		}
+		return rsa.SignPKCS1v15(rand.Reader, privateKey, hashType, digest(hashType, data))
+	case "PS":
+		privateKey, ok := key.privateKey.(*rsa.PrivateKey)
+		if !ok {
+			return nil, signingKeyError(key)
+		}
+		return rsa.SignPSS(rand.Reader, privateKey, hashType, digest(hashType, data), &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+		})
+	case "ES":
+		privateKey, ok := key.privateKey.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, signingKeyError(key)
+		}
+		curve := algorithmCurve(*algorithm)
+		if privateKey.Curve != curve {
+			return nil, jwt_errors.ErrInvalidKeyType
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest(hashType, data))
+		if err != nil {
+			return nil, err
+		}
+		// Подпись JWS для ECDSA — конкатенация R и S фиксированной длины (RFC 7518, 3.4)
+		size := (curve.Params().BitSize + 7) / 8
+		signature := make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+		return signature, nil
+	case "Ed":
+		privateKey, ok := key.privateKey.(ed25519.PrivateKey)
+		if !ok {
+			return nil, signingKeyError(key)
+		}
+		return ed25519.Sign(privateKey, data), nil
+	default:
+		return nil, jwt_errors.ErrUnsupportedAlgorithm
+	}
+}
+
+// signingKeyError различает отсутствие закрытого ключа и ключ неподходящего типа
+func signingKeyError(key *Key) error {
+	if key.privateKey == nil {
+		return jwt_errors.ErrMissingSigningKey
+	}
+	return jwt_errors.ErrInvalidKeyType
+}
+
 func parseHeader(headerBase64 *string) (*jwt_metadata.Header, error) {
 	headerBytes, err := base64.RawURLEncoding.DecodeString(*headerBase64)
 	if err != nil {
@@ -70,17 +161,78 @@ func parsePayload(payloadBase64 *string) (*jwt_metadata.Payload, error) {
 	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
 		return nil, errors.Join(jwt_errors.ErrInvalidPayloadFormat, err)
 	}
-	if err := payload.Validate(); err != nil {
-		return nil, err
-	}
 	return &payload, nil
 }
 
-func validateSignature(headerBase64, payloadBase64, signatureBase64, algorithm, secret *string) error {
-	verifySignature := generateSignature(headerBase64, payloadBase64, algorithm, secret)
-	verifySignatureBase64 := base64.RawURLEncoding.EncodeToString(verifySignature)
-	if !hmac.Equal([]byte(*signatureBase64), []byte(verifySignatureBase64)) {
+func validateSignature(headerBase64, payloadBase64, signatureBase64, algorithm *string, key *Key) error {
+	signature, err := base64.RawURLEncoding.DecodeString(*signatureBase64)
+	if err != nil {
+		return errors.Join(jwt_errors.ErrSignatureVerificationFailed, err)
+	}
+	hashType, err := algorithmHash(*algorithm)
+	if err != nil {
+		return err
+	}
+	data := signingInput(headerBase64, payloadBase64)
+
+	var isValid bool
+	switch (*algorithm)[:2] {
+	case "HS":
+		verifySignature, err := generateSignature(headerBase64, payloadBase64, algorithm, key)
+		if err != nil {
+			return err
+		}
+		isValid = hmac.Equal(signature, verifySignature)
+	case "RS":
+		publicKey, ok := key.publicKey.(*rsa.PublicKey)
+		if !ok {
+			return verifyKeyError(key)
+		}
+		isValid = rsa.VerifyPKCS1v15(publicKey, hashType, digest(hashType, data), signature) == nil
+	case "PS":
+		publicKey, ok := key.publicKey.(*rsa.PublicKey)
+		if !ok {
+			return verifyKeyError(key)
+		}
+		isValid = rsa.VerifyPSS(publicKey, hashType, digest(hashType, data), signature, &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthAuto,
+		}) == nil
+	case "ES":
+		publicKey, ok := key.publicKey.(*ecdsa.PublicKey)
+		if !ok {
+			return verifyKeyError(key)
+		}
+		curve := algorithmCurve(*algorithm)
+		if publicKey.Curve != curve {
+			return jwt_errors.ErrInvalidKeyType
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return jwt_errors.ErrSignatureVerificationFailed
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		isValid = ecdsa.Verify(publicKey, digest(hashType, data), r, s)
+	case "Ed":
+		publicKey, ok := key.publicKey.(ed25519.PublicKey)
+		if !ok {
+			return verifyKeyError(key)
+		}
+		isValid = ed25519.Verify(publicKey, data, signature)
+	default:
+		return jwt_errors.ErrUnsupportedAlgorithm
+	}
+
+	if !isValid {
 		return jwt_errors.ErrSignatureVerificationFailed
 	}
 	return nil
 }
+
+// verifyKeyError различает отсутствие открытого ключа и ключ неподходящего типа
+func verifyKeyError(key *Key) error {
+	if key.publicKey == nil {
+		return jwt_errors.ErrMissingVerifyKey
+	}
+	return jwt_errors.ErrInvalidKeyType
+}
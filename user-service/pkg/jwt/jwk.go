@@ -0,0 +1,57 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"sort"
+	"user-service/pkg/jwt/jwt_metadata"
+)
+
+// toJWK преобразует открытый ключ в JWK. Ключи HMAC не публикуются,
+// для них, как и для ключей без открытой части, возвращается false.
+func toJWK(alg string, key *Key) (jwt_metadata.JWK, bool) {
+	jwk := jwt_metadata.JWK{
+		Use: "sig",
+		Alg: alg,
+		Kid: key.id,
+	}
+
+	switch publicKey := key.publicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = publicKey.Curve.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, size)))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+	default:
+		return jwk, false
+	}
+	return jwk, true
+}
+
+// JWKS возвращает открытые ключи связки: активный и выведенные из оборота
+func (keyring *Keyring) JWKS() jwt_metadata.JWKSet {
+	jwkSet := jwt_metadata.JWKSet{
+		Keys: make([]jwt_metadata.JWK, 0, len(keyring.keys)),
+	}
+	for _, entry := range keyring.keys {
+		if jwk, ok := toJWK(entry.alg, entry.key); ok {
+			jwkSet.Keys = append(jwkSet.Keys, jwk)
+		}
+	}
+	sort.Slice(jwkSet.Keys, func(i, j int) bool {
+		return jwkSet.Keys[i].Kid < jwkSet.Keys[j].Kid
+	})
+	return jwkSet
+}
@@ -1,113 +1,207 @@
-package jwt
-
-import (
-	"encoding/base64"
-	"errors"
-	"strings"
-	"time"
-	"user-service/pkg/jwt/jwt_errors"
-	"user-service/pkg/jwt/jwt_metadata"
-)
-
-type JWTCoder struct {
-	alg                    string        // Алгоритм подписи
-	secret                 string        // Серкрет
-	issuer                 string        // Издательиздатель токена
-	audience               []string      // Получатели, которым предназначается данный токен
-	expirationTimeDuration time.Duration // Время жизни токена
-}
-
-func (c *JWTCoder) NewToken(subject, role string, permissions ...string) *jwt_metadata.Token {
-	header := jwt_metadata.Header{
-		Alg: c.alg,
-		Typ: "JWT",
-	}
-
-	payload := jwt_metadata.Payload{
-		BasePayload: jwt_metadata.BasePayload{
-			Issuer:  c.issuer,
-			Subject: subject,
-		},
-		Role:        role,
-		Permissions: permissions,
-	}
-	payload.SetAudience(c.audience)
-
-	timeNow := time.Now()
-	payload.SetExpiration(timeNow, c.expirationTimeDuration)
-	payload.SetNotBefore(timeNow, 0)
-	payload.SetIssuedAt(timeNow, 0)
-
-	return &jwt_metadata.Token{
-		Header:  header,
-		Payload: payload,
-	}
-}
-
-func (c *JWTCoder) Encode(token jwt_metadata.Token) (*string, error) {
-	header := &token.Header
-	if err := header.Validate(); err != nil {
-		return nil, errors.Join(jwt_errors.ErrTokenGeneration, err)
-	}
-	headerBase64, err := serializeToBase64(header)
-	if err != nil {
-		return nil, errors.Join(jwt_errors.ErrTokenGeneration, err)
-	}
-
-	payload := &token.Payload
-	if err := payload.Validate(); err != nil {
-		return nil, errors.Join(jwt_errors.ErrTokenGeneration, err)
-	}
-	payloadBase64, err := serializeToBase64(payload)
-	if err != nil {
-		return nil, errors.Join(jwt_errors.ErrTokenGeneration, err)
-	}
-
-	encodedSignature := generateSignature(headerBase64, payloadBase64, &header.Alg, &c.secret)
-
-	tokenParts := []string{
-		*headerBase64,
-		*payloadBase64,
-		string(base64.RawURLEncoding.EncodeToString(encodedSignature)),
-	}
-
-	jwt := strings.Join(tokenParts, ".")
-
-	return &jwt, nil
-}
-
-func (c *JWTCoder) Parse(token string) (*jwt_metadata.Token, error) {
-	tokenParts := strings.Split(token, ".")
-	if len(tokenParts) != 3 {
-		return nil, jwt_errors.ErrInvalidTokenFormat
-	}
-
-	header, err := parseHeader(&tokenParts[0])
-	if err != nil {
-		return nil, err
-	}
-
-	payload, err := parsePayload(&tokenParts[1])
-	if err != nil {
-		return nil, err
-	}
-
-	if err := validateSignature(&tokenParts[0], &tokenParts[1], &tokenParts[2], &header.Alg, &c.secret); err != nil {
-		return nil, err
-	}
-
-	return &jwt_metadata.Token{
-		Header:  *header,
-		Payload: *payload,
-	}, nil
-}
-
-func NewJWTCoder(alg, secret, issuer string, audience []string, expirationTimeDuration time.Duration) *JWTCoder {
-	return &JWTCoder{
-		alg:                    alg,
-		secret:                 secret,
-		issuer:                 issuer,
-		audience:               audience,
-		expirationTimeDuration: expirationTimeDuration,
-	}
-}
+package jwt
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"user-service/pkg/jwt/jwt_errors"
+	"user-service/pkg/jwt/jwt_metadata"
+)
+
+type JWTCoder struct {
+	keyring                *Keyring      // Ключи подписи и проверки
+	issuer                 string        // Издательиздатель токена
+	audience               []string      // Получатели, которым предназначается данный токен
+	expirationTimeDuration time.Duration // Время жизни токена
+	leeway                 time.Duration // Допустимое расхождение часов при проверке exp и nbf
+}
+
+func (c *JWTCoder) NewToken(subject string, roles []string, permissions ...string) *jwt_metadata.Token {
+	header := jwt_metadata.Header{
+		Alg: c.keyring.active.alg,
+		Typ: "JWT",
+		Kid: c.keyring.active.key.id,
+	}
+
+	payload := jwt_metadata.Payload{
+		BasePayload: jwt_metadata.BasePayload{
+			Issuer:  c.issuer,
+			Subject: subject,
+			JWTID:   newJWTID(),
+		},
+		Roles:       roles,
+		Permissions: permissions,
+	}
+	payload.SetAudience(c.audience)
+
+	timeNow := time.Now()
+	payload.SetExpiration(timeNow, c.expirationTimeDuration)
+	payload.SetNotBefore(timeNow, 0)
+	payload.SetIssuedAt(timeNow, 0)
+
+	return &jwt_metadata.Token{
+		Header:  header,
+		Payload: payload,
+	}
+}
+
+func (c *JWTCoder) Encode(token jwt_metadata.Token) (*string, error) {
+	header := &token.Header
+	if err := header.Validate(); err != nil {
+		return nil, errors.Join(jwt_errors.ErrTokenGeneration, err)
+	}
+	headerBase64, err := serializeToBase64(header)
+	if err != nil {
+		return nil, errors.Join(jwt_errors.ErrTokenGeneration, err)
+	}
+
+	payload := &token.Payload
+	if err := payload.Validate(); err != nil {
+		return nil, errors.Join(jwt_errors.ErrTokenGeneration, err)
+	}
+	payloadBase64, err := serializeToBase64(payload)
+	if err != nil {
+		return nil, errors.Join(jwt_errors.ErrTokenGeneration, err)
+	}
+
+	entry, err := c.keyring.lookup(header.Kid)
+	if err != nil {
+		return nil, errors.Join(jwt_errors.ErrTokenGeneration, err)
+	}
+	if header.Alg != entry.alg {
+		return nil, errors.Join(jwt_errors.ErrTokenGeneration, jwt_errors.ErrAlgorithmMismatch)
+	}
+	encodedSignature, err := generateSignature(headerBase64, payloadBase64, &header.Alg, entry.key)
+	if err != nil {
+		return nil, errors.Join(jwt_errors.ErrTokenGeneration, err)
+	}
+
+	tokenParts := []string{
+		*headerBase64,
+		*payloadBase64,
+		string(base64.RawURLEncoding.EncodeToString(encodedSignature)),
+	}
+
+	jwt := strings.Join(tokenParts, ".")
+
+	return &jwt, nil
+}
+
+func (c *JWTCoder) Parse(token string) (*jwt_metadata.Token, error) {
+	tokenParts := strings.Split(token, ".")
+	if len(tokenParts) != 3 {
+		return nil, jwt_errors.ErrInvalidTokenFormat
+	}
+
+	header, err := parseHeader(&tokenParts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := parsePayload(&tokenParts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := c.keyring.lookup(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	// Алгоритм берется из настроек ключа, а не из заголовка токена,
+	// иначе подписавший токен сам выбирает, как его проверять
+	if header.Alg != entry.alg {
+		return nil, jwt_errors.ErrAlgorithmMismatch
+	}
+
+	if err := validateSignature(&tokenParts[0], &tokenParts[1], &tokenParts[2], &entry.alg, entry.key); err != nil {
+		return nil, err
+	}
+
+	if err := payload.ValidateWithLeeway(c.leeway); err != nil {
+		return nil, err
+	}
+	if err := c.validateIssuer(payload); err != nil {
+		return nil, err
+	}
+	if err := c.validateAudience(payload); err != nil {
+		return nil, err
+	}
+
+	return &jwt_metadata.Token{
+		Header:  *header,
+		Payload: *payload,
+	}, nil
+}
+
+// WithLeeway задает допустимое расхождение часов при проверке exp и nbf
+func (c *JWTCoder) WithLeeway(leeway time.Duration) *JWTCoder {
+	c.leeway = leeway
+	return c
+}
+
+// Leeway возвращает допустимое расхождение часов при проверке exp и nbf
+func (c *JWTCoder) Leeway() time.Duration {
+	return c.leeway
+}
+
+// validateIssuer проверяет, что токен выпущен издателем, указанным в настройках кодировщика
+func (c *JWTCoder) validateIssuer(payload *jwt_metadata.Payload) error {
+	if c.issuer != "" && payload.Issuer != c.issuer {
+		return jwt_errors.ErrInvalidIssuer
+	}
+	return nil
+}
+
+// validateAudience проверяет, что хотя бы один получатель токена входит в список из настроек кодировщика
+func (c *JWTCoder) validateAudience(payload *jwt_metadata.Payload) error {
+	if len(c.audience) == 0 {
+		return nil
+	}
+	for _, audience := range payload.GetAudience() {
+		if slices.Contains(c.audience, audience) {
+			return nil
+		}
+	}
+	return jwt_errors.ErrInvalidAudience
+}
+
+// JWKS возвращает открытые ключи, которыми можно проверить выпущенные токены
+func (c *JWTCoder) JWKS() jwt_metadata.JWKSet {
+	return c.keyring.JWKS()
+}
+
+// newJWTID генерирует уникальный идентификатор токена (jti)
+func newJWTID() string {
+	jwtID := make([]byte, 16)
+	if _, err := rand.Read(jwtID); err != nil {
+		panic(errors.Join(jwt_errors.ErrTokenGeneration, err))
+	}
+	return hex.EncodeToString(jwtID)
+}
+
+// NewJWTCoder создает кодировщик для HMAC-алгоритмов с общим секретом
+func NewJWTCoder(alg, secret, issuer string, audience []string, expirationTimeDuration time.Duration) *JWTCoder {
+	return NewJWTCoderWithKey(alg, NewSecretKey(secret), issuer, audience, expirationTimeDuration)
+}
+
+// NewJWTCoderWithKey создает кодировщик с произвольным ключом, в том числе асимметричным.
+// Кодировщик с ключом, созданным через NewPublicKey, может только проверять токены.
+func NewJWTCoderWithKey(alg string, key *Key, issuer string, audience []string, expirationTimeDuration time.Duration) *JWTCoder {
+	return NewJWTCoderWithKeyring(NewKeyring(alg, key), issuer, audience, expirationTimeDuration)
+}
+
+// NewJWTCoderWithKeyring создает кодировщик, подписывающий активным ключом связки
+// и проверяющий токены ключом, указанным в заголовке kid
+func NewJWTCoderWithKeyring(keyring *Keyring, issuer string, audience []string, expirationTimeDuration time.Duration) *JWTCoder {
+	return &JWTCoder{
+		keyring:                keyring,
+		issuer:                 issuer,
+		audience:               audience,
+		expirationTimeDuration: expirationTimeDuration,
+	}
+}
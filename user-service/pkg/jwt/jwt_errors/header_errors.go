@@ -1,11 +1,12 @@
-package jwt_errors
-
-import "errors"
-
-var (
-	ErrInvalidHeader        = errors.New("неверный заголовок")
-	ErrInvalidHeaderFormat  = errors.New("неверный формат заголовка")
-	ErrUnsupportedTypeToken = errors.New("неподдерживаемый тип токена")
-	ErrMissingAlgorithm     = errors.New("алгоритм подписи не указан")
-	ErrUnsupportedAlgorithm = errors.New("неподдерживаемый алгоритм подписи")
-)
+package jwt_errors
+
+import "errors"
+
+var (
+	ErrInvalidHeader        = errors.New("неверный заголовок")
+	ErrInvalidHeaderFormat  = errors.New("неверный формат заголовка")
+	ErrUnsupportedTypeToken = errors.New("неподдерживаемый тип токена")
+	ErrMissingAlgorithm     = errors.New("алгоритм подписи не указан")
+	ErrUnsupportedAlgorithm = errors.New("неподдерживаемый алгоритм подписи")
+	ErrAlgorithmMismatch    = errors.New("алгоритм подписи не совпадает с настроенным для ключа")
+)
@@ -0,0 +1,12 @@
+package jwt_errors
+
+import "errors"
+
+var (
+	ErrMissingSigningKey = errors.New("ключ подписи не задан")
+	ErrMissingVerifyKey  = errors.New("ключ проверки подписи не задан")
+	ErrInvalidKeyType    = errors.New("тип ключа не соответствует алгоритму подписи")
+	ErrInvalidKeyFormat  = errors.New("неверный формат ключа")
+	ErrUnknownKeyID      = errors.New("неизвестный идентификатор ключа (kid)")
+	ErrDuplicateKeyID    = errors.New("повторяющийся идентификатор ключа (kid)")
+)
@@ -1,11 +1,14 @@
-package jwt_errors
-
-import "errors"
-
-var (
-	ErrInvalidPayload       = errors.New("неверная полезная нагрузка")
-	ErrInvalidPayloadFormat = errors.New("неверный формат полезной нагрузки")
-	ErrInvalidTimeRange     = errors.New("время истечения меньше времени начала действия")
-	ErrTokenExpired         = errors.New("токен истек")
-	ErrNotBeforeError       = errors.New("токен еще не активен (nbf)")
-)
+package jwt_errors
+
+import "errors"
+
+var (
+	ErrInvalidPayload        = errors.New("неверная полезная нагрузка")
+	ErrInvalidPayloadFormat  = errors.New("неверный формат полезной нагрузки")
+	ErrInvalidTimeRange      = errors.New("время истечения меньше времени начала действия")
+	ErrTokenExpired          = errors.New("токен истек")
+	ErrNotBeforeError        = errors.New("токен еще не активен (nbf)")
+	ErrInvalidIssuer         = errors.New("токен выпущен посторонним издателем (iss)")
+	ErrInvalidAudience       = errors.New("токен не предназначен для данного получателя (aud)")
+	ErrInvalidAudienceFormat = errors.New("неверный формат получателей (aud)")
+)
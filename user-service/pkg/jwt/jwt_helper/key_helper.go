@@ -0,0 +1,77 @@
+package jwt_helper
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"user-service/pkg/jwt/jwt_errors"
+)
+
+// ParsePrivateKeyPEM разбирает закрытый ключ RSA, ECDSA или Ed25519 в формате PEM
+// (PKCS#8, а также PKCS#1 для RSA и SEC 1 для ECDSA)
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, jwt_errors.ErrInvalidKeyFormat
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Join(jwt_errors.ErrInvalidKeyFormat, err)
+		}
+		return privateKey, nil
+	case "EC PRIVATE KEY":
+		privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Join(jwt_errors.ErrInvalidKeyFormat, err)
+		}
+		return privateKey, nil
+	case "PRIVATE KEY":
+		privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Join(jwt_errors.ErrInvalidKeyFormat, err)
+		}
+		signer, ok := privateKey.(crypto.Signer)
+		if !ok {
+			return nil, jwt_errors.ErrInvalidKeyType
+		}
+		return signer, nil
+	default:
+		return nil, jwt_errors.ErrInvalidKeyFormat
+	}
+}
+
+// ParsePublicKeyPEM разбирает открытый ключ RSA, ECDSA или Ed25519 в формате PEM
+// (PKIX, PKCS#1 для RSA или сертификат X.509)
+func ParsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, jwt_errors.ErrInvalidKeyFormat
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Join(jwt_errors.ErrInvalidKeyFormat, err)
+		}
+		return publicKey, nil
+	case "RSA PUBLIC KEY":
+		publicKey, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Join(jwt_errors.ErrInvalidKeyFormat, err)
+		}
+		return publicKey, nil
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Join(jwt_errors.ErrInvalidKeyFormat, err)
+		}
+		return certificate.PublicKey, nil
+	default:
+		return nil, jwt_errors.ErrInvalidKeyFormat
+	}
+}
@@ -0,0 +1,37 @@
+package jwt_metadata
+
+import (
+	"encoding/json"
+	"user-service/pkg/jwt/jwt_errors"
+)
+
+// Audience описывает получателей токена (aud). По RFC 7519 значение claim —
+// либо строка с единственным получателем, либо массив строк. Один получатель
+// сериализуется строкой, несколько — массивом; при разборе принимаются обе формы.
+type Audience []string
+
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		if single == "" {
+			*a = nil
+		} else {
+			*a = Audience{single}
+		}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return jwt_errors.ErrInvalidAudienceFormat
+	}
+	*a = Audience(multiple)
+	return nil
+}
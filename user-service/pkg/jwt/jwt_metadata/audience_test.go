@@ -0,0 +1,48 @@
+package jwt_metadata
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestAudience(t *testing.T) {
+	t.Run("Single audience as string", func(t *testing.T) {
+		data, err := json.Marshal(BasePayload{Audience: Audience{"user-service"}})
+		assert.Equal(t, err, nil)
+		assert.Equal(t, string(data), `{"sub":"","aud":"user-service","exp":0,"nbf":0,"iat":0}`)
+	})
+
+	t.Run("Multiple audiences as array", func(t *testing.T) {
+		data, err := json.Marshal(BasePayload{Audience: Audience{"user-service", "test-service"}})
+		assert.Equal(t, err, nil)
+		assert.Equal(t, string(data), `{"sub":"","aud":["user-service","test-service"],"exp":0,"nbf":0,"iat":0}`)
+	})
+
+	t.Run("Empty audience omitted", func(t *testing.T) {
+		data, err := json.Marshal(BasePayload{})
+		assert.Equal(t, err, nil)
+		assert.Equal(t, string(data), `{"sub":"","exp":0,"nbf":0,"iat":0}`)
+	})
+
+	t.Run("Parse string", func(t *testing.T) {
+		var payload BasePayload
+		err := json.Unmarshal([]byte(`{"aud":"user-service"}`), &payload)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, []string(payload.Audience), []string{"user-service"})
+	})
+
+	t.Run("Parse array", func(t *testing.T) {
+		var payload BasePayload
+		err := json.Unmarshal([]byte(`{"aud":["user-service","test-service"]}`), &payload)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, []string(payload.Audience), []string{"user-service", "test-service"})
+	})
+
+	t.Run("Parse invalid", func(t *testing.T) {
+		var payload BasePayload
+		err := json.Unmarshal([]byte(`{"aud":42}`), &payload)
+		assert.NotEqual(t, err, nil)
+	})
+}
@@ -0,0 +1,19 @@
+package jwt_metadata
+
+// JWK описывает открытый ключ в формате JSON Web Key (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`           // тип ключа: RSA, EC или OKP
+	Use string `json:"use,omitempty"` // назначение ключа
+	Alg string `json:"alg,omitempty"` // алгоритм подписи
+	Kid string `json:"kid,omitempty"` // идентификатор ключа
+	N   string `json:"n,omitempty"`   // модуль RSA
+	E   string `json:"e,omitempty"`   // открытая экспонента RSA
+	Crv string `json:"crv,omitempty"` // кривая EC или OKP
+	X   string `json:"x,omitempty"`   // координата X (EC) или открытый ключ (OKP)
+	Y   string `json:"y,omitempty"`   // координата Y (EC)
+}
+
+// JWKSet описывает набор открытых ключей, публикуемый для проверки токенов
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
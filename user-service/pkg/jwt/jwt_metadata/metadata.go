@@ -1,5 +1,5 @@
-package jwt_metadata
-
-type SerializebleBase64 interface {
-	Header | Payload
-}
+package jwt_metadata
+
+type SerializebleBase64 interface {
+	Header | Payload
+}
@@ -0,0 +1,331 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"user-service/pkg/jwt/jwt_errors"
+	"user-service/pkg/jwt/jwt_helper"
+
+	"github.com/go-playground/assert/v2"
+)
+
+const (
+	testIssuer   string        = "user-service"
+	testDuration time.Duration = time.Minute * 15
+)
+
+var testAudience = []string{"user-service"}
+
+func TestAsymmetricAlgorithms(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		alg string
+		key *Key
+	}{
+		{"HS256", NewSecretKey("secret")},
+		{"HS384", NewSecretKey("secret")},
+		{"HS512", NewSecretKey("secret")},
+		{"RS256", NewPrivateKey(rsaKey)},
+		{"RS384", NewPrivateKey(rsaKey)},
+		{"RS512", NewPrivateKey(rsaKey)},
+		{"PS256", NewPrivateKey(rsaKey)},
+		{"PS384", NewPrivateKey(rsaKey)},
+		{"PS512", NewPrivateKey(rsaKey)},
+		{"ES256", NewPrivateKey(p256Key)},
+		{"ES384", NewPrivateKey(p384Key)},
+		{"EdDSA", NewPrivateKey(ed25519Key)},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.alg, func(t *testing.T) {
+			coder := NewJWTCoderWithKey(testCase.alg, testCase.key, testIssuer, testAudience, testDuration)
+			encoded, err := coder.Encode(*coder.NewToken("1", []string{"user"}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			token, err := coder.Parse(*encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, token.Header.Alg, testCase.alg)
+			assert.Equal(t, token.Payload.Subject, "1")
+
+			// Асимметричные подписи должны проверяться только открытым ключом
+			if testCase.key.privateKey != nil {
+				verifier := NewJWTCoderWithKey(testCase.alg, NewPublicKey(testCase.key.publicKey), testIssuer, testAudience, testDuration)
+				_, err := verifier.Parse(*encoded)
+				assert.Equal(t, err, nil)
+
+				_, err = verifier.Encode(*verifier.NewToken("1", []string{"user"}))
+				assert.Equal(t, errors.Is(err, jwt_errors.ErrMissingSigningKey), true)
+			}
+		})
+	}
+
+	t.Run("Wrong curve", func(t *testing.T) {
+		coder := NewJWTCoderWithKey("ES384", NewPrivateKey(p256Key), testIssuer, testAudience, testDuration)
+		_, err := coder.Encode(*coder.NewToken("1", []string{"user"}))
+		assert.Equal(t, errors.Is(err, jwt_errors.ErrInvalidKeyType), true)
+	})
+
+	t.Run("Foreign key", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		coder := NewJWTCoderWithKey("ES256", NewPrivateKey(p256Key), testIssuer, testAudience, testDuration)
+		verifier := NewJWTCoderWithKey("ES256", NewPublicKey(otherKey.Public()), testIssuer, testAudience, testDuration)
+		encoded, err := coder.Encode(*coder.NewToken("1", []string{"user"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = verifier.Parse(*encoded)
+		assert.Equal(t, errors.Is(err, jwt_errors.ErrSignatureVerificationFailed), true)
+	})
+
+	t.Run("HMAC with public key", func(t *testing.T) {
+		coder := NewJWTCoderWithKey("RS256", NewPrivateKey(rsaKey), testIssuer, testAudience, testDuration)
+		token := coder.NewToken("1", []string{"admin"})
+		token.Header.Alg = "HS256"
+		_, err := coder.Encode(*token)
+		assert.Equal(t, errors.Is(err, jwt_errors.ErrAlgorithmMismatch), true)
+
+		publicDER, err := x509.MarshalPKIXPublicKey(rsaKey.Public())
+		if err != nil {
+			t.Fatal(err)
+		}
+		forger := NewJWTCoder("HS256", string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})), testIssuer, testAudience, testDuration)
+		forged, err := forger.Encode(*forger.NewToken("1", []string{"admin"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = coder.Parse(*forged)
+		assert.Equal(t, errors.Is(err, jwt_errors.ErrAlgorithmMismatch), true)
+	})
+}
+
+func TestClaimsValidation(t *testing.T) {
+	coder := NewJWTCoder("HS256", "secret", testIssuer, []string{"user-service", "test-service"}, testDuration)
+
+	t.Run("Valid claims", func(t *testing.T) {
+		otherCoder := NewJWTCoder("HS256", "secret", testIssuer, []string{"test-service", "other-service"}, testDuration)
+		encoded, err := otherCoder.Encode(*otherCoder.NewToken("1", []string{"user"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = coder.Parse(*encoded)
+		assert.Equal(t, err, nil)
+	})
+
+	t.Run("Foreign issuer", func(t *testing.T) {
+		otherCoder := NewJWTCoder("HS256", "secret", "other-service", testAudience, testDuration)
+		encoded, err := otherCoder.Encode(*otherCoder.NewToken("1", []string{"user"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = coder.Parse(*encoded)
+		assert.Equal(t, errors.Is(err, jwt_errors.ErrInvalidIssuer), true)
+	})
+
+	t.Run("Foreign audience", func(t *testing.T) {
+		otherCoder := NewJWTCoder("HS256", "secret", testIssuer, []string{"other-service"}, testDuration)
+		encoded, err := otherCoder.Encode(*otherCoder.NewToken("1", []string{"user"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = coder.Parse(*encoded)
+		assert.Equal(t, errors.Is(err, jwt_errors.ErrInvalidAudience), true)
+	})
+
+	t.Run("Algorithm mismatch", func(t *testing.T) {
+		otherCoder := NewJWTCoder("HS512", "secret", testIssuer, testAudience, testDuration)
+		encoded, err := otherCoder.Encode(*otherCoder.NewToken("1", []string{"user"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = coder.Parse(*encoded)
+		assert.Equal(t, errors.Is(err, jwt_errors.ErrAlgorithmMismatch), true)
+	})
+
+	t.Run("Leeway", func(t *testing.T) {
+		expiredCoder := NewJWTCoder("HS256", "secret", testIssuer, testAudience, -time.Second*10)
+		token := expiredCoder.NewToken("1", []string{"user"})
+		token.Payload.SetNotBefore(time.Now(), -time.Minute)
+		headerBase64, err := serializeToBase64(&token.Header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		payloadBase64, err := serializeToBase64(&token.Payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature, err := generateSignature(headerBase64, payloadBase64, &token.Header.Alg, NewSecretKey("secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded := *headerBase64 + "." + *payloadBase64 + "." + base64.RawURLEncoding.EncodeToString(signature)
+
+		_, err = coder.Parse(encoded)
+		assert.Equal(t, errors.Is(err, jwt_errors.ErrTokenExpired), true)
+
+		lenientCoder := NewJWTCoder("HS256", "secret", testIssuer, testAudience, testDuration).WithLeeway(time.Second * 30)
+		_, err = lenientCoder.Parse(encoded)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, lenientCoder.Leeway(), time.Second*30)
+	})
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, newKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldCoder := NewJWTCoderWithKey("ES256", NewPrivateKey(oldKey).WithID("old"), testIssuer, testAudience, testDuration)
+	oldToken, err := oldCoder.Encode(*oldCoder.NewToken("1", []string{"user"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyring := NewKeyring("EdDSA", NewPrivateKey(newKey).WithID("new"))
+	if err := keyring.AddRetired("ES256", NewPublicKey(oldKey.Public()).WithID("old")); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, errors.Is(keyring.AddRetired("ES256", NewPublicKey(oldKey.Public()).WithID("old")), jwt_errors.ErrDuplicateKeyID), true)
+	coder := NewJWTCoderWithKeyring(keyring, testIssuer, testAudience, testDuration)
+
+	t.Run("New tokens use active key", func(t *testing.T) {
+		token := coder.NewToken("1", []string{"user"})
+		assert.Equal(t, token.Header.Kid, "new")
+		assert.Equal(t, token.Header.Alg, "EdDSA")
+
+		encoded, err := coder.Encode(*token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = coder.Parse(*encoded)
+		assert.Equal(t, err, nil)
+	})
+
+	t.Run("Retired key still verifies", func(t *testing.T) {
+		token, err := coder.Parse(*oldToken)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, token.Header.Kid, "old")
+	})
+
+	t.Run("Unknown kid", func(t *testing.T) {
+		otherCoder := NewJWTCoderWithKey("ES256", NewPrivateKey(oldKey).WithID("other"), testIssuer, testAudience, testDuration)
+		encoded, err := otherCoder.Encode(*otherCoder.NewToken("1", []string{"user"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = coder.Parse(*encoded)
+		assert.Equal(t, errors.Is(err, jwt_errors.ErrUnknownKeyID), true)
+	})
+
+	t.Run("JWKS", func(t *testing.T) {
+		jwkSet := coder.JWKS()
+		assert.Equal(t, len(jwkSet.Keys), 2)
+		assert.Equal(t, jwkSet.Keys[0].Kid, "new")
+		assert.Equal(t, jwkSet.Keys[0].Kty, "OKP")
+		assert.Equal(t, jwkSet.Keys[1].Kid, "old")
+		assert.Equal(t, jwkSet.Keys[1].Kty, "EC")
+		assert.Equal(t, jwkSet.Keys[1].Crv, "P-256")
+
+		hmacCoder := NewJWTCoder("HS256", "secret", testIssuer, testAudience, testDuration)
+		assert.Equal(t, len(hmacCoder.JWKS().Keys), 0)
+	})
+}
+
+func TestParseKeyPEM(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsedPrivate, err := jwt_helper.ParsePrivateKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, privateKey.Equal(parsedPrivate), true)
+
+	parsedPublic, err := jwt_helper.ParsePublicKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, privateKey.PublicKey.Equal(parsedPublic), true)
+
+	_, err = jwt_helper.ParsePrivateKeyPEM([]byte("not a pem"))
+	assert.Equal(t, errors.Is(err, jwt_errors.ErrInvalidKeyFormat), true)
+}
+
+func TestKeyValidate(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		alg      string
+		key      *Key
+		signing  bool
+		expected error
+	}{
+		{name: "HMAC", alg: "HS256", key: NewSecretKey("secret"), signing: true, expected: nil},
+		{name: "Empty secret", alg: "HS256", key: NewSecretKey(""), signing: true, expected: jwt_errors.ErrMissingSigningKey},
+		{name: "Unsupported algorithm", alg: "HS1", key: NewSecretKey("secret"), signing: true, expected: jwt_errors.ErrUnsupportedAlgorithm},
+		{name: "Empty algorithm", alg: "", key: NewSecretKey("secret"), signing: true, expected: jwt_errors.ErrUnsupportedAlgorithm},
+		{name: "ECDSA", alg: "ES256", key: NewPrivateKey(ecKey), signing: true, expected: nil},
+		{name: "Wrong curve", alg: "ES384", key: NewPrivateKey(ecKey), signing: true, expected: jwt_errors.ErrInvalidKeyType},
+		{name: "Wrong key type", alg: "RS256", key: NewPrivateKey(ecKey), signing: true, expected: jwt_errors.ErrInvalidKeyType},
+		{name: "RSA PSS", alg: "PS256", key: NewPrivateKey(rsaKey), signing: true, expected: nil},
+		{name: "Public key verifies", alg: "RS256", key: NewPublicKey(rsaKey.Public()), signing: false, expected: nil},
+		{name: "Public key cannot sign", alg: "RS256", key: NewPublicKey(rsaKey.Public()), signing: true, expected: jwt_errors.ErrMissingSigningKey},
+		{name: "Missing key", alg: "EdDSA", key: &Key{}, signing: false, expected: jwt_errors.ErrMissingVerifyKey},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.key.Validate(test.alg, test.signing), test.expected)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"user-service/pkg/jwt/jwt_errors"
+)
+
+// Key содержит ключевой материал для подписи и проверки токенов.
+// Для HMAC-алгоритмов используется секрет, для асимметричных — пара ключей,
+// при этом для проверки подписи достаточно только открытого ключа.
+type Key struct {
+	id         string           // Идентификатор ключа (kid)
+	secret     []byte           // Секрет для HMAC
+	privateKey crypto.Signer    // Закрытый ключ для асимметричной подписи
+	publicKey  crypto.PublicKey // Открытый ключ для проверки асимметричной подписи
+}
+
+// NewSecretKey создает ключ HMAC из секрета
+func NewSecretKey(secret string) *Key {
+	return &Key{
+		secret: []byte(secret),
+	}
+}
+
+// NewPrivateKey создает ключ подписи, открытый ключ извлекается из закрытого
+func NewPrivateKey(privateKey crypto.Signer) *Key {
+	return &Key{
+		privateKey: privateKey,
+		publicKey:  privateKey.Public(),
+	}
+}
+
+// NewPublicKey создает ключ, пригодный только для проверки подписи
+func NewPublicKey(publicKey crypto.PublicKey) *Key {
+	return &Key{
+		publicKey: publicKey,
+	}
+}
+
+// WithID задает идентификатор ключа (kid), по которому ключ выбирается при проверке токена
+func (key *Key) WithID(id string) *Key {
+	key.id = id
+	return key
+}
+
+// ID возвращает идентификатор ключа (kid)
+func (key *Key) ID() string {
+	return key.id
+}
+
+// Validate проверяет, что алгоритм alg поддерживается и ключ ему подходит.
+// Если signing, ключ также должен позволять подписывать токены.
+func (key *Key) Validate(alg string, signing bool) error {
+	if _, err := algorithmHash(alg); err != nil {
+		return err
+	}
+	if alg[:2] == "HS" {
+		if len(key.secret) == 0 {
+			return jwt_errors.ErrMissingSigningKey
+		}
+		return nil
+	}
+
+	var isValid bool
+	switch publicKey := key.publicKey.(type) {
+	case nil:
+		return jwt_errors.ErrMissingVerifyKey
+	case *rsa.PublicKey:
+		isValid = alg[:2] == "RS" || alg[:2] == "PS"
+	case *ecdsa.PublicKey:
+		isValid = publicKey.Curve == algorithmCurve(alg)
+	case ed25519.PublicKey:
+		isValid = alg == "EdDSA"
+	}
+	if !isValid {
+		return jwt_errors.ErrInvalidKeyType
+	}
+	if signing && key.privateKey == nil {
+		return jwt_errors.ErrMissingSigningKey
+	}
+	return nil
+}
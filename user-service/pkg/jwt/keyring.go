@@ -0,0 +1,52 @@
+package jwt
+
+import "user-service/pkg/jwt/jwt_errors"
+
+// keyringEntry связывает ключ с алгоритмом, которым он подписывает токены
+type keyringEntry struct {
+	alg string
+	key *Key
+}
+
+// Keyring хранит один активный ключ подписи и ключи, выведенные из оборота.
+// Выведенные ключи используются только для проверки токенов, выпущенных до ротации,
+// пока те не истекут.
+type Keyring struct {
+	active *keyringEntry
+	keys   map[string]*keyringEntry // Ключи по идентификатору (kid)
+}
+
+// AddRetired добавляет ключ, пригодный только для проверки подписи
+func (keyring *Keyring) AddRetired(alg string, key *Key) error {
+	if _, isExist := keyring.keys[key.id]; isExist {
+		return jwt_errors.ErrDuplicateKeyID
+	}
+	keyring.keys[key.id] = &keyringEntry{
+		alg: alg,
+		key: key,
+	}
+	return nil
+}
+
+// lookup возвращает запись ключа по идентификатору из заголовка токена
+func (keyring *Keyring) lookup(kid string) (*keyringEntry, error) {
+	entry, isExist := keyring.keys[kid]
+	if !isExist {
+		return nil, jwt_errors.ErrUnknownKeyID
+	}
+	return entry, nil
+}
+
+// NewKeyring создает связку ключей с активным ключом подписи
+func NewKeyring(alg string, activeKey *Key) *Keyring {
+	active := &keyringEntry{
+		alg: alg,
+		key: activeKey,
+	}
+	return &Keyring{
+		active: active,
+		keys: map[string]*keyringEntry{
+			activeKey.id: active,
+		},
+	}
+}
@@ -0,0 +1,11 @@
+package migrate
+
+import "errors"
+
+var (
+	ErrInvalidFileName   = errors.New("имя файла миграции должно иметь вид <версия>_<имя>.up.sql или <версия>_<имя>.down.sql")
+	ErrDuplicateVersion  = errors.New("версия миграции повторяется")
+	ErrMissingScript     = errors.New("для миграции должны быть заданы оба скрипта: up и down")
+	ErrUnknownVersion    = errors.New("миграция с заданной версией не найдена")
+	ErrNothingToRollback = errors.New("нет примененных миграций")
+)
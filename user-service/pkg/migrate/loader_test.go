@@ -0,0 +1,76 @@
+package migrate
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func script(data string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(data)}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Sorted by version", func(t *testing.T) {
+		migrations, err := Load(fstest.MapFS{
+			"0010_add_index.up.sql":      script("CREATE INDEX"),
+			"0010_add_index.down.sql":    script("DROP INDEX"),
+			"0002_create_users.up.sql":   script("CREATE TABLE"),
+			"0002_create_users.down.sql": script("DROP TABLE"),
+		})
+		assert.Equal(t, err, nil)
+		assert.Equal(t, len(migrations), 2)
+		assert.Equal(t, migrations[0], Migration{Version: 2, Name: "create_users", Up: "CREATE TABLE", Down: "DROP TABLE"})
+		assert.Equal(t, migrations[1].Version, int64(10))
+		assert.Equal(t, migrations[1].Name, "add_index")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		migrations, err := Load(fstest.MapFS{})
+		assert.Equal(t, err, nil)
+		assert.Equal(t, len(migrations), 0)
+	})
+
+	t.Run("Invalid file name", func(t *testing.T) {
+		_, err := Load(fstest.MapFS{
+			"create_users.sql": script("CREATE TABLE"),
+		})
+		assert.Equal(t, errors.Is(err, ErrInvalidFileName), true)
+	})
+
+	t.Run("Zero version", func(t *testing.T) {
+		_, err := Load(fstest.MapFS{
+			"0000_init.up.sql":   script("CREATE TABLE"),
+			"0000_init.down.sql": script("DROP TABLE"),
+		})
+		assert.Equal(t, errors.Is(err, ErrInvalidFileName), true)
+	})
+
+	t.Run("Missing down", func(t *testing.T) {
+		_, err := Load(fstest.MapFS{
+			"0001_init.up.sql": script("CREATE TABLE"),
+		})
+		assert.Equal(t, errors.Is(err, ErrMissingScript), true)
+	})
+
+	t.Run("Duplicate version", func(t *testing.T) {
+		_, err := Load(fstest.MapFS{
+			"0001_init.up.sql":    script("CREATE TABLE"),
+			"0001_init.down.sql":  script("DROP TABLE"),
+			"0001_other.up.sql":   script("CREATE INDEX"),
+			"0001_other.down.sql": script("DROP INDEX"),
+		})
+		assert.Equal(t, errors.Is(err, ErrDuplicateVersion), true)
+	})
+
+	t.Run("Same version with different padding", func(t *testing.T) {
+		_, err := Load(fstest.MapFS{
+			"1_init.up.sql":      script("CREATE TABLE"),
+			"0001_init.up.sql":   script("CREATE TABLE"),
+			"0001_init.down.sql": script("DROP TABLE"),
+		})
+		assert.Equal(t, errors.Is(err, ErrDuplicateVersion), true)
+	})
+}
@@ -0,0 +1,124 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix string = "$argon2id$"
+
+// Argon2idParams содержит параметры Argon2id
+type Argon2idParams struct {
+	Memory      uint32 // Объем памяти в КиБ
+	Iterations  uint32 // Число проходов
+	Parallelism uint8  // Число потоков
+	SaltLength  uint32 // Длина соли в байтах
+	KeyLength   uint32 // Длина хеша в байтах
+}
+
+// DefaultArgon2idParams — минимальные параметры, рекомендованные OWASP
+var DefaultArgon2idParams = Argon2idParams{
+	Memory:      19 * 1024,
+	Iterations:  2,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+type argon2idHasher struct {
+	params Argon2idParams
+}
+
+// Hash implements Hasher.
+// Результат в формате PHC: $argon2id$v=19$m=<память>,t=<проходы>,p=<потоки>$<соль>$<хеш>
+func (hasher *argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, hasher.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey(
+		[]byte(password),
+		salt,
+		hasher.params.Iterations,
+		hasher.params.Memory,
+		hasher.params.Parallelism,
+		hasher.params.KeyLength,
+	)
+	return fmt.Sprintf(
+		"%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		hasher.params.Memory,
+		hasher.params.Iterations,
+		hasher.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify implements Hasher.
+func (hasher *argon2idHasher) Verify(password, passwordHash string) (bool, error) {
+	params, salt, key, err := decodeArgon2id(passwordHash)
+	if err != nil {
+		return false, err
+	}
+	verifyKey := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return subtle.ConstantTimeCompare(key, verifyKey) == 1, nil
+}
+
+// Supports implements Hasher.
+func (hasher *argon2idHasher) Supports(passwordHash string) bool {
+	return strings.HasPrefix(passwordHash, argon2idPrefix)
+}
+
+// NeedsRehash implements Hasher.
+func (hasher *argon2idHasher) NeedsRehash(passwordHash string) bool {
+	params, _, _, err := decodeArgon2id(passwordHash)
+	if err != nil {
+		return true
+	}
+	return params != hasher.params
+}
+
+// decodeArgon2id разбирает хеш в формате PHC и возвращает параметры, соль и ключ
+func decodeArgon2id(passwordHash string) (Argon2idParams, []byte, []byte, error) {
+	var params Argon2idParams
+
+	parts := strings.Split(passwordHash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, ErrInvalidHashFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrInvalidHashFormat
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrInvalidHashFormat
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrInvalidHashFormat
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, ErrInvalidHashFormat
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
+
+// NewArgon2idHasher создает Hasher на основе Argon2id
+func NewArgon2idHasher(params Argon2idParams) Hasher {
+	return &argon2idHasher{
+		params: params,
+	}
+}
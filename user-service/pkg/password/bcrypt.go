@@ -0,0 +1,55 @@
+package password
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type bcryptHasher struct {
+	cost int
+}
+
+// Hash implements Hasher.
+// Результат в модульном формате bcrypt: $2a$<стоимость>$<соль и хеш>
+func (hasher *bcryptHasher) Hash(password string) (string, error) {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), hasher.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(passwordHash), nil
+}
+
+// Verify implements Hasher.
+func (hasher *bcryptHasher) Verify(password, passwordHash string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password))
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+		return false, nil
+	default:
+		return false, errors.Join(ErrInvalidHashFormat, err)
+	}
+}
+
+// Supports implements Hasher.
+func (hasher *bcryptHasher) Supports(passwordHash string) bool {
+	return strings.HasPrefix(passwordHash, "$2a$") ||
+		strings.HasPrefix(passwordHash, "$2b$") ||
+		strings.HasPrefix(passwordHash, "$2y$")
+}
+
+// NeedsRehash implements Hasher.
+func (hasher *bcryptHasher) NeedsRehash(passwordHash string) bool {
+	cost, err := bcrypt.Cost([]byte(passwordHash))
+	return err != nil || cost != hasher.cost
+}
+
+// NewBcryptHasher создает Hasher на основе bcrypt
+func NewBcryptHasher(cost int) Hasher {
+	return &bcryptHasher{
+		cost: cost,
+	}
+}
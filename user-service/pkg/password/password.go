@@ -0,0 +1,56 @@
+package password
+
+import "errors"
+
+var (
+	ErrUnsupportedHash   = errors.New("неподдерживаемый формат хеша пароля")
+	ErrInvalidHashFormat = errors.New("неверный формат хеша пароля")
+)
+
+// Hasher хеширует и проверяет пароли одним алгоритмом
+type Hasher interface {
+	// Hash возвращает хеш пароля со встроенными параметрами алгоритма
+	Hash(password string) (string, error)
+	// Verify проверяет пароль по хешу, созданному этим алгоритмом
+	Verify(password, passwordHash string) (bool, error)
+	// Supports сообщает, создан ли хеш этим алгоритмом
+	Supports(passwordHash string) bool
+	// NeedsRehash сообщает, что хеш создан этим алгоритмом, но с устаревшими параметрами
+	NeedsRehash(passwordHash string) bool
+}
+
+// PasswordHasher хеширует новые пароли текущим алгоритмом и проверяет хеши
+// всех поддерживаемых алгоритмов, чтобы устаревшие хеши можно было заменить при входе
+type PasswordHasher struct {
+	current Hasher
+	hashers []Hasher
+}
+
+// Hash хеширует пароль текущим алгоритмом
+func (passwordHasher *PasswordHasher) Hash(password string) (string, error) {
+	return passwordHasher.current.Hash(password)
+}
+
+// Verify проверяет пароль и сообщает, нужно ли перехешировать его текущим алгоритмом
+func (passwordHasher *PasswordHasher) Verify(password, passwordHash string) (isValid bool, needsRehash bool, err error) {
+	for _, hasher := range passwordHasher.hashers {
+		if !hasher.Supports(passwordHash) {
+			continue
+		}
+		isValid, err = hasher.Verify(password, passwordHash)
+		if err != nil || !isValid {
+			return false, false, err
+		}
+		return true, hasher != passwordHasher.current || hasher.NeedsRehash(passwordHash), nil
+	}
+	return false, false, ErrUnsupportedHash
+}
+
+// New создает PasswordHasher с текущим алгоритмом current и алгоритмами legacy,
+// хеши которых принимаются при проверке, но подлежат замене
+func New(current Hasher, legacy ...Hasher) *PasswordHasher {
+	return &PasswordHasher{
+		current: current,
+		hashers: append([]Hasher{current}, legacy...),
+	}
+}
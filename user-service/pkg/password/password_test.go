@@ -0,0 +1,118 @@
+package password
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var testArgon2idParams = Argon2idParams{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestHashers(t *testing.T) {
+	tests := []struct {
+		name   string
+		hasher Hasher
+		prefix string
+	}{
+		{name: "argon2id", hasher: NewArgon2idHasher(testArgon2idParams), prefix: "$argon2id$v=19$m=1024,t=1,p=1$"},
+		{name: "bcrypt", hasher: NewBcryptHasher(bcrypt.MinCost), prefix: "$2a$04$"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			passwordHash, err := test.hasher.Hash("secret")
+			assert.Equal(t, err, nil)
+			assert.Equal(t, strings.HasPrefix(passwordHash, test.prefix), true)
+			assert.Equal(t, test.hasher.Supports(passwordHash), true)
+			assert.Equal(t, test.hasher.NeedsRehash(passwordHash), false)
+
+			isValid, err := test.hasher.Verify("secret", passwordHash)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, isValid, true)
+
+			isValid, err = test.hasher.Verify("wrong", passwordHash)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, isValid, false)
+
+			// Соль случайная, поэтому одинаковые пароли дают разные хеши
+			otherHash, _ := test.hasher.Hash("secret")
+			assert.NotEqual(t, otherHash, passwordHash)
+		})
+	}
+}
+
+func TestArgon2idNeedsRehash(t *testing.T) {
+	oldHash, _ := NewArgon2idHasher(testArgon2idParams).Hash("secret")
+
+	params := testArgon2idParams
+	params.Iterations = 2
+	hasher := NewArgon2idHasher(params)
+	assert.Equal(t, hasher.NeedsRehash(oldHash), true)
+
+	// Хеш со старыми параметрами проверяется по параметрам из самого хеша
+	isValid, err := hasher.Verify("secret", oldHash)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, isValid, true)
+}
+
+func TestArgon2idInvalidFormat(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2idParams)
+	for _, passwordHash := range []string{
+		"$argon2id$",
+		"$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=x,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$!!!$a2V5",
+	} {
+		_, err := hasher.Verify("secret", passwordHash)
+		assert.Equal(t, err, ErrInvalidHashFormat)
+	}
+}
+
+func TestPasswordHasherRehash(t *testing.T) {
+	legacySum := sha256.Sum256([]byte("secret"))
+	legacyHash := hex.EncodeToString(legacySum[:])
+	bcryptHash, _ := NewBcryptHasher(bcrypt.MinCost).Hash("secret")
+	argon2idHash, _ := NewArgon2idHasher(testArgon2idParams).Hash("secret")
+
+	passwordHasher := New(
+		NewArgon2idHasher(testArgon2idParams),
+		NewBcryptHasher(bcrypt.MinCost),
+		NewSHA256Hasher(),
+	)
+
+	tests := []struct {
+		name        string
+		hash        string
+		password    string
+		isValid     bool
+		needsRehash bool
+		err         error
+	}{
+		{name: "current", hash: argon2idHash, password: "secret", isValid: true, needsRehash: false},
+		{name: "bcrypt", hash: bcryptHash, password: "secret", isValid: true, needsRehash: true},
+		{name: "sha256", hash: legacyHash, password: "secret", isValid: true, needsRehash: true},
+		{name: "sha256 wrong password", hash: legacyHash, password: "wrong", isValid: false, needsRehash: false},
+		{name: "unsupported", hash: "plain", password: "plain", isValid: false, needsRehash: false, err: ErrUnsupportedHash},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			isValid, needsRehash, err := passwordHasher.Verify(test.password, test.hash)
+			assert.Equal(t, err, test.err)
+			assert.Equal(t, isValid, test.isValid)
+			assert.Equal(t, needsRehash, test.needsRehash)
+		})
+	}
+
+	newHash, err := passwordHasher.Hash("secret")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.HasPrefix(newHash, argon2idPrefix), true)
+}
@@ -0,0 +1,42 @@
+package password
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+// sha256Hasher проверяет несоленые хеши SHA-256, которые хранились до перехода на Argon2id.
+// Используется только как устаревший алгоритм: такие хеши заменяются при следующем входе.
+type sha256Hasher struct{}
+
+// Hash implements Hasher.
+func (hasher *sha256Hasher) Hash(password string) (string, error) {
+	passwordHash := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(passwordHash[:]), nil
+}
+
+// Verify implements Hasher.
+func (hasher *sha256Hasher) Verify(password, passwordHash string) (bool, error) {
+	verifyHash, _ := hasher.Hash(password)
+	return subtle.ConstantTimeCompare([]byte(verifyHash), []byte(passwordHash)) == 1, nil
+}
+
+// Supports implements Hasher.
+func (hasher *sha256Hasher) Supports(passwordHash string) bool {
+	if len(passwordHash) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(passwordHash)
+	return err == nil
+}
+
+// NeedsRehash implements Hasher.
+func (hasher *sha256Hasher) NeedsRehash(passwordHash string) bool {
+	return true
+}
+
+// NewSHA256Hasher создает Hasher для устаревших хешей SHA-256
+func NewSHA256Hasher() Hasher {
+	return &sha256Hasher{}
+}
@@ -0,0 +1,11 @@
+package patch
+
+import "errors"
+
+var (
+	ErrInvalidDocument  = errors.New("документ изменений не является корректным JSON")
+	ErrInvalidOperation = errors.New("некорректная операция JSON Patch")
+	ErrInvalidPath      = errors.New("некорректный путь JSON Pointer")
+	ErrPathNotFound     = errors.New("путь отсутствует в документе")
+	ErrTestFailed       = errors.New("проверка test не пройдена")
+)
@@ -0,0 +1,312 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSONPatchContentType — тип содержимого документа JSON Patch (RFC 6902)
+const JSONPatchContentType string = "application/json-patch+json"
+
+// Operation — операция JSON Patch. Пути задаются в формате JSON Pointer (RFC 6901).
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Apply применяет документ JSON Patch (RFC 6902) к документу target.
+// Операции выполняются по порядку; если любая из них не удалась, возвращается ошибка
+// и документ не изменяется. Поддерживаются операции add, remove, replace, move, copy и test.
+func Apply(target []byte, patch []byte) ([]byte, error) {
+	var operations []Operation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, ErrInvalidDocument
+	}
+	document, err := decode(target)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, operation := range operations {
+		document, err = operation.apply(document)
+		if err != nil {
+			return nil, fmt.Errorf("операция %d (%s %s): %w", i, operation.Op, operation.Path, err)
+		}
+	}
+	return json.Marshal(document)
+}
+
+func (operation Operation) apply(document any) (any, error) {
+	path, err := parsePointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add":
+		value, err := operation.value()
+		if err != nil {
+			return nil, err
+		}
+		return add(document, path, value)
+	case "remove":
+		return remove(document, path)
+	case "replace":
+		value, err := operation.value()
+		if err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return value, nil
+		}
+		if document, err = remove(document, path); err != nil {
+			return nil, err
+		}
+		return add(document, path, value)
+	case "move", "copy":
+		from, err := parsePointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(document, from)
+		if err != nil {
+			return nil, err
+		}
+		if operation.Op == "copy" {
+			return add(document, path, deepCopy(value))
+		}
+		if isPrefix(from, path) && len(from) != len(path) {
+			return nil, fmt.Errorf("%w: нельзя переместить значение внутрь самого себя", ErrInvalidOperation)
+		}
+		if document, err = remove(document, from); err != nil {
+			return nil, err
+		}
+		return add(document, path, value)
+	case "test":
+		expected, err := operation.value()
+		if err != nil {
+			return nil, err
+		}
+		actual, err := get(document, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(actual, expected) {
+			return nil, ErrTestFailed
+		}
+		return document, nil
+	default:
+		return nil, fmt.Errorf("%w: неизвестная операция %q", ErrInvalidOperation, operation.Op)
+	}
+}
+
+func (operation Operation) value() (any, error) {
+	if operation.Value == nil {
+		return nil, fmt.Errorf("%w: не задано значение value", ErrInvalidOperation)
+	}
+	return decode(operation.Value)
+}
+
+// parsePointer разбирает JSON Pointer на токены. Пустая строка указывает на весь документ.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPath, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func isPrefix(prefix []string, path []string) bool {
+	return len(prefix) <= len(path) && reflect.DeepEqual(prefix, path[:len(prefix)])
+}
+
+// get возвращает значение по пути path
+func get(document any, path []string) (any, error) {
+	node := document
+	for _, token := range path {
+		var err error
+		if node, err = child(node, token); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// add вставляет value по пути path. В массив значение вставляется перед элементом
+// с заданным индексом, токен "-" добавляет значение в конец массива.
+func add(document any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return modify(document, path, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			node[token] = value
+			return node, nil
+		case []any:
+			if token == "-" {
+				return append(node, value), nil
+			}
+			index, err := arrayIndex(token, len(node)+1)
+			if err != nil {
+				return nil, err
+			}
+			return append(node[:index], append([]any{value}, node[index:]...)...), nil
+		default:
+			return nil, ErrPathNotFound
+		}
+	})
+}
+
+// remove удаляет значение по пути path
+func remove(document any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: нельзя удалить весь документ", ErrInvalidOperation)
+	}
+	return modify(document, path, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			if _, ok := node[token]; !ok {
+				return nil, ErrPathNotFound
+			}
+			delete(node, token)
+			return node, nil
+		case []any:
+			index, err := arrayIndex(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+			return append(node[:index], node[index+1:]...), nil
+		default:
+			return nil, ErrPathNotFound
+		}
+	})
+}
+
+// modify находит контейнер, содержащий последний токен пути, применяет к нему action
+// и подставляет измененный контейнер обратно в документ
+func modify(node any, path []string, action func(container any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return action(node, path[0])
+	}
+	childNode, err := child(node, path[0])
+	if err != nil {
+		return nil, err
+	}
+	childNode, err = modify(childNode, path[1:], action)
+	if err != nil {
+		return nil, err
+	}
+
+	switch container := node.(type) {
+	case map[string]any:
+		container[path[0]] = childNode
+	case []any:
+		index, _ := arrayIndex(path[0], len(container))
+		container[index] = childNode
+	}
+	return node, nil
+}
+
+func child(node any, token string) (any, error) {
+	switch container := node.(type) {
+	case map[string]any:
+		value, ok := container[token]
+		if !ok {
+			return nil, ErrPathNotFound
+		}
+		return value, nil
+	case []any:
+		index, err := arrayIndex(token, len(container))
+		if err != nil {
+			return nil, err
+		}
+		return container[index], nil
+	default:
+		return nil, ErrPathNotFound
+	}
+}
+
+// arrayIndex разбирает индекс массива: неотрицательное число без ведущих нулей меньше limit
+func arrayIndex(token string, limit int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: некорректный индекс массива %q", ErrInvalidPath, token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("%w: некорректный индекс массива %q", ErrInvalidPath, token)
+	}
+	if index >= limit {
+		return 0, ErrPathNotFound
+	}
+	return index, nil
+}
+
+func deepCopy(value any) any {
+	switch node := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(node))
+		for key, item := range node {
+			copied[key] = deepCopy(item)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(node))
+		for i, item := range node {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	default:
+		return value
+	}
+}
+
+// equal сравнивает значения JSON; числа сравниваются по значению, а не по записи
+func equal(left any, right any) bool {
+	switch leftNode := left.(type) {
+	case json.Number:
+		rightNumber, ok := right.(json.Number)
+		if !ok {
+			return false
+		}
+		leftFloat, leftErr := leftNode.Float64()
+		rightFloat, rightErr := rightNumber.Float64()
+		return leftErr == nil && rightErr == nil && leftFloat == rightFloat
+	case map[string]any:
+		rightNode, ok := right.(map[string]any)
+		if !ok || len(leftNode) != len(rightNode) {
+			return false
+		}
+		for key, value := range leftNode {
+			rightValue, ok := rightNode[key]
+			if !ok || !equal(value, rightValue) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		rightNode, ok := right.([]any)
+		if !ok || len(leftNode) != len(rightNode) {
+			return false
+		}
+		for i := range leftNode {
+			if !equal(leftNode[i], rightNode[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return left == right
+	}
+}
@@ -0,0 +1,58 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MergePatchContentType — тип содержимого документа JSON Merge Patch (RFC 7396)
+const MergePatchContentType string = "application/merge-patch+json"
+
+// Merge применяет документ JSON Merge Patch (RFC 7396) к документу target.
+// Поля со значением null удаляются, объекты объединяются рекурсивно,
+// остальные значения, в том числе массивы, заменяются целиком.
+func Merge(target []byte, patch []byte) ([]byte, error) {
+	targetValue, err := decode(target)
+	if err != nil {
+		return nil, err
+	}
+	patchValue, err := decode(patch)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeValue(targetValue, patchValue))
+}
+
+func mergeValue(target any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any, len(patchObject))
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergeValue(targetObject[key], value)
+	}
+	return targetObject
+}
+
+// decode читает JSON, сохраняя числа как json.Number, чтобы не терять точность
+func decode(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, ErrInvalidDocument
+	}
+	if decoder.More() {
+		return nil, ErrInvalidDocument
+	}
+	return value, nil
+}
@@ -0,0 +1,107 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+// assertJSON сравнивает документы JSON без учета порядка полей
+func assertJSON(t *testing.T, actual []byte, expected string) {
+	t.Helper()
+	var actualValue, expectedValue any
+	if err := json.Unmarshal(actual, &actualValue); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(expected), &expectedValue); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, actualValue, expectedValue)
+}
+
+func TestMerge(t *testing.T) {
+	// Примеры из приложения A RFC 7396
+	cases := []struct {
+		name     string
+		target   string
+		patch    string
+		expected string
+	}{
+		{"Replace value", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"Add value", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"Remove value", `{"a":"b"}`, `{"a":null}`, `{}`},
+		{"Nested object", `{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{"Replace array", `{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{"Non-object patch", `{"a":"foo"}`, `["c"]`, `["c"]`},
+		{"Non-object target", `["a","b"]`, `{"a":"b"}`, `{"a":"b"}`},
+		{"Nested null", `{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := Merge([]byte(testCase.target), []byte(testCase.patch))
+			assert.Equal(t, err, nil)
+			assertJSON(t, result, testCase.expected)
+		})
+	}
+
+	t.Run("Invalid patch", func(t *testing.T) {
+		_, err := Merge([]byte(`{}`), []byte(`{"a":`))
+		assert.Equal(t, errors.Is(err, ErrInvalidDocument), true)
+	})
+}
+
+func TestApply(t *testing.T) {
+	// Примеры из приложения A RFC 6902
+	cases := []struct {
+		name     string
+		target   string
+		patch    string
+		expected string
+	}{
+		{"Add object member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{"Add array element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"Append array element", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc"]}]`, `{"foo":["bar",["abc"]]}`},
+		{"Remove object member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"Remove array element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"Replace value", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{"Move value", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{"Move array element", `{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{"Copy value", `{"foo":{"bar":1}}`, `[{"op":"copy","from":"/foo","path":"/baz"},{"op":"replace","path":"/foo/bar","value":2}]`, `{"foo":{"bar":2},"baz":{"bar":1}}`},
+		{"Test success", `{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2.0}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{"Escaped path", `{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10},{"op":"replace","path":"/~1","value":1}]`, `{"/":1,"~1":10}`},
+		{"Null value", `{"foo":"bar"}`, `[{"op":"add","path":"/foo","value":null}]`, `{"foo":null}`},
+	}
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := Apply([]byte(testCase.target), []byte(testCase.patch))
+			assert.Equal(t, err, nil)
+			assertJSON(t, result, testCase.expected)
+		})
+	}
+
+	errorCases := []struct {
+		name     string
+		target   string
+		patch    string
+		expected error
+	}{
+		{"Test failure", `{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`, ErrTestFailed},
+		{"Missing object member", `{"foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, ErrPathNotFound},
+		{"Missing parent", `{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`, ErrPathNotFound},
+		{"Index out of range", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/2","value":"qux"}]`, ErrPathNotFound},
+		{"Leading zero index", `{"foo":["bar","baz"]}`, `[{"op":"remove","path":"/foo/01"}]`, ErrInvalidPath},
+		{"Relative path", `{"foo":"bar"}`, `[{"op":"remove","path":"foo"}]`, ErrInvalidPath},
+		{"Unknown operation", `{"foo":"bar"}`, `[{"op":"rename","path":"/foo"}]`, ErrInvalidOperation},
+		{"Missing value", `{"foo":"bar"}`, `[{"op":"add","path":"/baz"}]`, ErrInvalidOperation},
+		{"Move into itself", `{"foo":{"bar":1}}`, `[{"op":"move","from":"/foo","path":"/foo/bar/baz"}]`, ErrInvalidOperation},
+		{"Not an array", `{"op":"add","path":"/foo","value":1}`, `{"op":"add"}`, ErrInvalidDocument},
+	}
+	for _, testCase := range errorCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := Apply([]byte(testCase.target), []byte(testCase.patch))
+			assert.Equal(t, errors.Is(err, testCase.expected), true)
+		})
+	}
+}
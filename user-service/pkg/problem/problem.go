@@ -0,0 +1,49 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ContentType — тип содержимого ответа с описанием ошибки по RFC 7807
+const ContentType string = "application/problem+json"
+
+// DefaultType означает, что ошибка не имеет собственного типа и описывается кодом ответа
+const DefaultType string = "about:blank"
+
+// Problem — описание ошибки в формате RFC 7807
+type Problem struct {
+	Type     string       `json:"type"`             // URI типа ошибки
+	Title    string       `json:"title"`            // Краткое описание типа ошибки
+	Status   int          `json:"status"`           // Код ответа HTTP
+	Detail   string       `json:"detail,omitempty"` // Описание конкретного случая ошибки
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"` // Ошибки проверки отдельных полей
+}
+
+// FieldError описывает поле запроса, не прошедшее проверку
+type FieldError struct {
+	Field      string `json:"field"`             // Имя поля в запросе
+	Constraint string `json:"constraint"`        // Нарушенное ограничение: required, min, max
+	Param      string `json:"param,omitempty"`   // Параметр ограничения, например минимальная длина
+	Message    string `json:"message,omitempty"` // Описание ошибки на языке запроса
+}
+
+// New создает описание ошибки без собственного типа: заголовком служит текст кода ответа
+func New(status int, detail string) *Problem {
+	return &Problem{
+		Type:   DefaultType,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// Write отправляет описание ошибки с типом содержимого application/problem+json
+func Write(responseWriter http.ResponseWriter, problem *Problem) error {
+	header := responseWriter.Header()
+	header.Set("Content-Type", ContentType)
+	header.Set("X-Content-Type-Options", "nosniff")
+	responseWriter.WriteHeader(problem.Status)
+	return json.NewEncoder(responseWriter).Encode(problem)
+}
@@ -0,0 +1,48 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestWrite(t *testing.T) {
+	t.Run("Default type", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		err := Write(res, New(http.StatusNotFound, "пользователь не найден"))
+		assert.Equal(t, err, nil)
+		assert.Equal(t, res.Code, http.StatusNotFound)
+		assert.Equal(t, res.Header().Get("Content-Type"), ContentType)
+
+		var body map[string]any
+		assert.Equal(t, json.NewDecoder(res.Body).Decode(&body), nil)
+		assert.Equal(t, body["type"], DefaultType)
+		assert.Equal(t, body["title"], "Not Found")
+		assert.Equal(t, body["status"], float64(http.StatusNotFound))
+		assert.Equal(t, body["detail"], "пользователь не найден")
+		_, hasErrors := body["errors"]
+		assert.Equal(t, hasErrors, false)
+	})
+
+	t.Run("Field errors", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		problem := &Problem{
+			Type:     "/problems/validation",
+			Title:    "Некорректные данные",
+			Status:   http.StatusBadRequest,
+			Instance: "/users",
+			Errors: []FieldError{
+				{Field: "username", Constraint: "min", Param: "3"},
+				{Field: "password", Constraint: "required"},
+			},
+		}
+		assert.Equal(t, Write(res, problem), nil)
+
+		var decoded Problem
+		assert.Equal(t, json.NewDecoder(res.Body).Decode(&decoded), nil)
+		assert.Equal(t, decoded, *problem)
+	})
+}
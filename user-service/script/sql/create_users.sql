@@ -1,15 +1,21 @@
-\c user_service;
+-- Тестовые пользователи для локальной разработки.
+-- Выполняется после миграций: роли и права создаются миграцией 0002_seed_roles.
 
 DELETE FROM users;
 
-INSERT INTO users (username, password_hash) VALUES ('admin', 'admin');
-INSERT INTO users (username, password_hash) VALUES ('user0', 'user0');
-INSERT INTO users (username, password_hash) VALUES ('user1', 'user1');
-INSERT INTO users (username, password_hash) VALUES ('user2', 'user2');
-INSERT INTO users (username, password_hash) VALUES ('user3', 'user3');
-INSERT INTO users (username, password_hash) VALUES ('user4', 'user4');
-INSERT INTO users (username, password_hash) VALUES ('user5', 'user5');
-INSERT INTO users (username, password_hash) VALUES ('user6', 'user6');
-INSERT INTO users (username, password_hash) VALUES ('user7', 'user7');
-INSERT INTO users (username, password_hash) VALUES ('user8', 'user8');
-INSERT INTO users (username, password_hash) VALUES ('user9', 'user9');
\ No newline at end of file
+INSERT INTO users (username, password_hash) VALUES ('admin', encode(sha256('admin'), 'hex'));
+INSERT INTO users (username, password_hash) VALUES ('user0', encode(sha256('user0'), 'hex'));
+INSERT INTO users (username, password_hash) VALUES ('user1', encode(sha256('user1'), 'hex'));
+INSERT INTO users (username, password_hash) VALUES ('user2', encode(sha256('user2'), 'hex'));
+INSERT INTO users (username, password_hash) VALUES ('user3', encode(sha256('user3'), 'hex'));
+INSERT INTO users (username, password_hash) VALUES ('user4', encode(sha256('user4'), 'hex'));
+INSERT INTO users (username, password_hash) VALUES ('user5', encode(sha256('user5'), 'hex'));
+INSERT INTO users (username, password_hash) VALUES ('user6', encode(sha256('user6'), 'hex'));
+INSERT INTO users (username, password_hash) VALUES ('user7', encode(sha256('user7'), 'hex'));
+INSERT INTO users (username, password_hash) VALUES ('user8', encode(sha256('user8'), 'hex'));
+INSERT INTO users (username, password_hash) VALUES ('user9', encode(sha256('user9'), 'hex'));
+
+INSERT INTO user_roles (user_id, role_id)
+SELECT users.id, roles.id FROM users, roles WHERE roles.name = 'user';
+INSERT INTO user_roles (user_id, role_id)
+SELECT users.id, roles.id FROM users, roles WHERE users.username = 'admin' AND roles.name = 'admin';
\ No newline at end of file